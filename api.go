@@ -5,13 +5,14 @@
 
 /*
  * Created: 15th August 2015
- * Updated: 1st March 2025
+ * Updated: 19th October 2026
  */
 
 package clasp
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"strings"
 )
@@ -35,6 +36,9 @@ const (
 	Parse_DontRecogniseDoubleHyphenToStartValues                            // Recognises a double hyphen command-line argument - `"--"` - as an instruction to treat all remaining arguments as values.
 	Parse_DontMergeBitFlagsIntoBitFlags64                                   // Suppresses the default behaviour to mix into the `int64` result matched `int` bitFlagss (see [Specification.SetBitFlags]) when no matched `int64` bitFlagss (see [Specification.SetBitFlags64]) are specified.
 	Parse_DontMarkUsedDuringParseWhenMatchingBitFlags                       // Suppresses the default behaviour to mark as used (see [Argument.Use]) flags that have been provided receiver variables in [Specification.SetBitFlags] or [Specification.SetBitFlags64].
+	Parse_ValidateSpecifications                                            // Causes [Parse] to validate the specifications (see [ValidateSpecifications]) before parsing, and to panic if they are invalid.
+	Parse_StopAtFirstValue                                                  // Causes the first value, and all command-line arguments that follow it, to be treated as values, regardless of their form (see [Arguments.Remainder]).
+	Parse_HonourPosixlyCorrect                                              // Causes [Parse_StopAtFirstValue] to be in effect if the environment variable `POSIXLY_CORRECT` is defined (see [ParseParams.LookupEnv]).
 )
 
 const (
@@ -72,6 +76,12 @@ type Specification struct {
 
 	flags_receiver   *int
 	flags64_receiver *int64
+	completer        func(prefix string) []string
+	completion_hint  CompletionHint
+	localised_help   map[string]string
+	hidden           bool
+	deprecation      *deprecation_info
+	advanced         bool
 }
 
 // Structure that defines a parsed argument.
@@ -84,8 +94,13 @@ type Argument struct {
 	NumGivenHyphens       int
 	ArgumentSpecification *Specification
 	Flags                 int
+	AfterDoubleHyphen     bool // Indicates that the argument is a value that follows the double hyphen - "--" - and so was treated as a value regardless of its form.
+	Deprecated            bool // Indicates that the argument matched a deprecated specification (see [Specification.SetDeprecated]).
+	CharOffset            int  // The offset, in bytes, within the command-line argument at CmdLineIndex of the character from which the argument was obtained, if it was obtained from a compound flag - e.g. 2 for the flag "-q" from "-xqz" - or otherwise 0 (see [Arguments.FormatDiagnostic]).
 
-	used_ int
+	use_ *argument_use // the use state, which is shared by all copies of the argument
+
+	alias_specification_ *Specification // the specification of the alias - e.g. "-c" for "--verbosity=chatty" - via which an option was given, if any
 }
 
 // Structure that defines result of parsing (see [Parse]).
@@ -97,29 +112,52 @@ type Arguments struct {
 	Argv        []string    // The original argument string array passed to [Parse].
 	ProgramName string      // The program name.
 
-	specifications []*Specification
-	bitFlags       int
-	bitFlags64     int64
+	DoubleHyphenIndex int // The index in Argv of the double hyphen - "--" - after which all arguments are values, or -1 if there is none (or it is not recognised; see [Parse_DontRecogniseDoubleHyphenToStartValues]).
+
+	remainder_index int
+	parse_flags     ParseFlag // the parse flags in effect, including Parse_StopAtFirstValue if that is in effect by POSIXLY_CORRECT
+	specifications  []*Specification
+	bitFlags        int
+	bitFlags64      int64
+	messages        MessageCatalogue
+	locale          string
 }
 
 // Structure that defines parse options (see [Parse]).
 type ParseParams struct {
 	Specifications []Specification
 	Flags          ParseFlag
+	Messages       MessageCatalogue // The catalogue of messages used in preference to the built-in messages for Locale in parse errors (see [UnrecognisedArgumentError]). May be `nil`.
+	Locale         string           // The locale - e.g. "de_DE" - of parse errors. If empty, parse errors are in English.
+	// Function used to look up environment variables when
+	// [Parse_HonourPosixlyCorrect] is specified. If `nil`, [os.LookupEnv]
+	// is used.
+	LookupEnv func(key string) (string, bool)
+	// The sink to which warnings - e.g. of the use of deprecated arguments
+	// (see [Specification.SetDeprecated]) - are given. If `nil`, warnings
+	// are written to [os.Stderr].
+	WarningSink WarningSink
+}
+
+// Records the boundaries observed by parse_arguments_(), each of which is
+// an index in argv, or -1 if there is none.
+type parse_bounds struct {
+	double_hyphen_index int // the index of the recognised "--"
+	remainder_index     int // the index of the first of the remaining arguments that are treated as values
 }
 
 // Obtains, by value, a specification containing a stock specification of a '--help' flag.
 func HelpFlag() Specification {
 
 	// TODO: reimplement in terms of [Flag] ??
-	return Specification{FlagType, "--help", nil, "Shows this help and exits", nil, 0, 0, nil, nil, nil}
+	return Specification{Type: FlagType, Name: "--help", Help: "Shows this help and exits"}
 }
 
 // Obtains, by value, a specification containing a stock specification of a '--version' flag.
 func VersionFlag() Specification {
 
 	// TODO: reimplement in terms of [Flag] ??
-	return Specification{FlagType, "--version", nil, "Shows version information and exits", nil, 0, 0, nil, nil, nil}
+	return Specification{Type: FlagType, Name: "--version", Help: "Shows version information and exits"}
 }
 
 func (at ArgType) String() string {
@@ -177,7 +215,7 @@ func (specification Specification) String() string {
 
 func (argument Argument) String() string {
 
-	return fmt.Sprintf("<%T{ ResolvedName=%q, GivenName=%q, Value=%q, Type=%v, CmdLineIndex=%d, NumGivenHyphens=%d, ArgumentSpecification=%v, Flags=0x%x, used=%t }>", argument, argument.ResolvedName, argument.GivenName, argument.Value, argument.Type, argument.CmdLineIndex, argument.NumGivenHyphens, argument.ArgumentSpecification, argument.Flags, argument.used_ != 0)
+	return fmt.Sprintf("<%T{ ResolvedName=%q, GivenName=%q, Value=%q, Type=%v, CmdLineIndex=%d, NumGivenHyphens=%d, ArgumentSpecification=%v, Flags=0x%x, used=%t }>", argument, argument.ResolvedName, argument.GivenName, argument.Value, argument.Type, argument.CmdLineIndex, argument.NumGivenHyphens, argument.ArgumentSpecification, argument.Flags, argument.use_.is_used())
 }
 
 func (arguments Arguments) String() string {
@@ -316,6 +354,14 @@ func (specification Specification) End() Specification {
  * helpers
  */
 
+// Protocol for finding the specification that matches a flag/option name
+// or alias, as used by [Parse] (by linear search of the specifications)
+// and [Parser] (by indexes).
+type specification_finder interface {
+	findSpecification(name string) (found bool, specification *Specification, specificationIndex int)
+	findShortSpecification(c rune) (found bool, specification *Specification, specificationIndex int)
+}
+
 func (params *ParseParams) findSpecification(name string) (found bool, specification *Specification, specificationIndex int) {
 
 	// Algorithm:
@@ -346,26 +392,62 @@ func (params *ParseParams) findSpecification(name string) (found bool, specifica
 	return false, nil, -1
 }
 
+// Indicates whether parsing stops at the first value, either because
+// [Parse_StopAtFirstValue] is specified or because
+// [Parse_HonourPosixlyCorrect] is specified and `POSIXLY_CORRECT` is
+// defined.
+func stops_at_first_value(params *ParseParams) bool {
+
+	if 0 != (Parse_StopAtFirstValue & params.Flags) {
+
+		return true
+	}
+
+	if 0 != (Parse_HonourPosixlyCorrect & params.Flags) {
+
+		lookupEnv := params.LookupEnv
+
+		if nil == lookupEnv {
+
+			lookupEnv = os.LookupEnv
+		}
+
+		_, defined := lookupEnv("POSIXLY_CORRECT")
+
+		return defined
+	}
+
+	return false
+}
+
+func (params *ParseParams) findShortSpecification(c rune) (found bool, specification *Specification, specificationIndex int) {
+
+	return params.findSpecification(fmt.Sprintf("-%c", c))
+}
+
 /* /////////////////////////////////////////////////////////////////////////
  * API
  */
 
 // Marks an argument as used, such that it will not be obtained in a call to
 // [Arguments.GetUnusedFlags] / [Arguments.GetUnusedOptions] /
-// [Arguments.GetUnusedFlagsAndOptions].
+// [Arguments.GetUnusedFlagsAndOptions] / [Arguments.GetUnusedValues].
+//
+// NOTE: Values are never marked used by [Arguments], so a program that
+// wishes to report unexpected values (see [Arguments.UnusedValuesError])
+// must mark used each value that it processes.
 //
-// NOTE: This is meaningful only to arguments whose [Argument.Type] is
-// [FlagType] or [OptionType]. A future version may issue a panic if called
-// on another argument type.
+// NOTE: This may be called concurrently from multiple goroutines (see
+// [Argument.Claim]).
 func (arg *Argument) Use() {
 
 	// TODO: switch on `FlagType` / `OptionType` and warn in other cases
 
-	arg.used_ = 1
+	arg.use_state().mark_used()
 }
 
 func (arg Argument) isUnused() bool {
-	return 0 == arg.used_
+	return !arg.use_.is_used()
 }
 
 // T.B.C.
@@ -385,6 +467,21 @@ func (arg Argument) Str() string {
 // T.B.C.
 func Parse(argv []string, params ParseParams) *Arguments {
 
+	if 0 != (Parse_ValidateSpecifications & params.Flags) {
+
+		if err := ValidateSpecifications(params.Specifications); err != nil {
+
+			panic(fmt.Sprintf("invoked Parse() with invalid specifications:\n%v", err))
+		}
+	}
+
+	return parse_(argv, &params, &params, nil)
+}
+
+// Parses the given arguments, finding specifications by the given finder.
+// If specifications is `nil`, the specifications are copied from params.
+func parse_(argv []string, params *ParseParams, finder specification_finder, specifications []*Specification) *Arguments {
+
 	args := new(Arguments)
 
 	args.Arguments = make([]*Argument, 0)
@@ -392,6 +489,8 @@ func Parse(argv []string, params ParseParams) *Arguments {
 	args.Options = make([]*Argument, 0)
 	args.Values = make([]*Argument, 0)
 	args.Argv = argv
+	args.messages = params.Messages
+	args.locale = params.Locale
 	if len(argv) > 0 {
 
 		args.ProgramName = path.Base(argv[0])
@@ -400,35 +499,198 @@ func Parse(argv []string, params ParseParams) *Arguments {
 		args.ProgramName = ""
 	}
 
+	bounds := parse_bounds{-1, -1}
+
+	parse_arguments_(argv, params, finder, &bounds, func(arg *Argument) error {
+
+		// the argument is reused by parse_arguments_(), so is copied
+
+		a := new(Argument)
+
+		*a = *arg
+
+		a.use_state()
+
+		args.Arguments = append(args.Arguments, a)
+
+		return nil
+	})
+
+	args.DoubleHyphenIndex = bounds.double_hyphen_index
+	args.remainder_index = bounds.remainder_index
+	args.parse_flags = params.Flags
+	if stops_at_first_value(params) {
+
+		args.parse_flags |= Parse_StopAtFirstValue
+	}
+
+	for _, arg := range args.Arguments {
+
+		switch arg.Type {
+
+		case FlagType:
+
+			args.Flags = append(args.Flags, arg)
+		case OptionType:
+
+			args.Options = append(args.Options, arg)
+		case ValueType:
+
+			args.Values = append(args.Values, arg)
+		}
+	}
+
+	if nil != specifications {
+
+		args.specifications = specifications
+	} else {
+
+		args.specifications = make([]*Specification, len(params.Specifications))
+
+		for i, spec := range params.Specifications {
+
+			var p *Specification = new(Specification)
+
+			*p = spec
+
+			args.specifications[i] = p
+		}
+	}
+
+	// now process the bit flags
+
+	for _, arg := range args.Flags {
+
+		bitFlags, bitFlags64 := apply_bit_flags(arg, params.Flags)
+
+		args.bitFlags |= bitFlags
+		args.bitFlags64 |= bitFlags64
+	}
+
+	return args
+}
+
+// Applies the bit flags of the specification of the given flag argument,
+// if any, to their flags receiver variables, marking the argument used
+// unless [Parse_DontMarkUsedDuringParseWhenMatchingBitFlags] is
+// specified, and obtains them.
+func apply_bit_flags(arg *Argument, flags ParseFlag) (bitFlags int, bitFlags64 int64) {
+
+	spec := arg.ArgumentSpecification
+
+	if nil != spec {
+
+		if 0 != spec.BitFlags64 {
+
+			if nil != spec.flags64_receiver {
+
+				*spec.flags64_receiver |= spec.BitFlags64
+
+				if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & flags) {
+
+					arg.Use()
+				}
+			}
+
+			bitFlags64 |= spec.BitFlags64
+		} else {
+			if 0 != spec.BitFlags {
+
+				if nil != spec.flags_receiver {
+
+					*spec.flags_receiver |= spec.BitFlags
+
+					if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & flags) {
+
+						arg.Use()
+					}
+				}
+
+				bitFlags |= spec.BitFlags
+
+				if 0 == (Parse_DontMergeBitFlagsIntoBitFlags64 & flags) {
+
+					if nil != spec.flags64_receiver {
+
+						*spec.flags64_receiver |= spec.BitFlags64
+
+						if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & flags) {
+
+							arg.Use()
+						}
+					}
+
+					bitFlags64 |= int64(spec.BitFlags)
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// Parses the given arguments, finding specifications by the given finder,
+// and invoking emit for each argument as it is recognised, stopping at
+// (and returning) any error that emit returns. If bounds is not `nil`, the
+// boundaries observed are recorded in it.
+//
+// NOTE: the argument passed to emit is reused for each flag, option, and
+// value, other than for compound flags.
+func parse_arguments_(argv []string, params *ParseParams, finder specification_finder, bounds *parse_bounds, emit func(arg *Argument) error) error {
+
 	treatingAsValues := false
 	nextIsOptValue := false
+	stopAtFirstValue := stops_at_first_value(params)
+	stopped := false
+
+	if nil == bounds {
+
+		bounds = &parse_bounds{}
+	}
+
+	emit = deprecation_emitter(argv, params, emit)
+
+	bounds.double_hyphen_index = -1
+	bounds.remainder_index = -1
+
+	var scratch Argument
 
 	if len(argv) > 0 {
 		for i, s := range argv[1:] {
 
-			if !treatingAsValues && "--" == s && (0 == (params.Flags & Parse_DontRecogniseDoubleHyphenToStartValues)) {
+			if !treatingAsValues && !stopped && "--" == s && (0 == (params.Flags & Parse_DontRecogniseDoubleHyphenToStartValues)) {
 
 				treatingAsValues = true
+				bounds.double_hyphen_index = i + 1
+				bounds.remainder_index = i + 2
 				continue
 			}
 
 			if nextIsOptValue {
 
 				nextIsOptValue = false
-				args.Arguments[len(args.Arguments)-1].Value = s
+				scratch.Value = s
+
+				if err := emit(&scratch); err != nil {
+
+					return err
+				}
 				continue
 			}
 
-			arg := new(Argument)
+			arg := &scratch
+
+			*arg = Argument{}
 
 			arg.CmdLineIndex = i + 1
 			arg.Flags = int(params.Flags)
 			arg.ArgumentSpecification = nil
+			arg.AfterDoubleHyphen = treatingAsValues
 
 			numHyphens := 0
 			isSingle := false
 
-			if !treatingAsValues {
+			if !treatingAsValues && !stopped {
 
 				l := len(s)
 				if 1 == l && "-" == s {
@@ -462,7 +724,7 @@ func Parse(argv []string, params ParseParams) *Arguments {
 					arg.ResolvedName = nv[0]
 					arg.Value = nv[1]
 
-					if found, specification, _ := params.findSpecification(arg.ResolvedName); found {
+					if found, specification, _ := finder.findSpecification(arg.ResolvedName); found {
 
 						arg.ResolvedName = specification.Name
 						arg.ArgumentSpecification = specification
@@ -478,7 +740,7 @@ func Parse(argv []string, params ParseParams) *Arguments {
 					resolvedName := s
 					argType := FlagType
 
-					if found, specification, _ := params.findSpecification(s); found {
+					if found, specification, _ := finder.findSpecification(s); found {
 
 						resolvedName = specification.Name
 						argType = specification.Type
@@ -493,10 +755,11 @@ func Parse(argv []string, params ParseParams) *Arguments {
 							s = resolvedName
 							resolvedName = res_nm
 							arg.Value = value
+							arg.alias_specification_ = specification
 
 							// Now need to look up the actual underlying specification
 
-							if actualFound, actualSpecification, _ := params.findSpecification(res_nm); actualFound {
+							if actualFound, actualSpecification, _ := finder.findSpecification(res_nm); actualFound {
 
 								arg.ArgumentSpecification = actualSpecification
 							}
@@ -518,9 +781,7 @@ func Parse(argv []string, params ParseParams) *Arguments {
 								continue
 							}
 
-							testAlias := fmt.Sprintf("-%c", c)
-
-							if compoundFound, compoundSpec, _ := params.findSpecification(testAlias); compoundFound && compoundSpec.Type == FlagType {
+							if compoundFound, compoundSpec, _ := finder.findShortSpecification(c); compoundFound && compoundSpec.Type == FlagType {
 
 								var compoundArg Argument
 
@@ -529,6 +790,7 @@ func Parse(argv []string, params ParseParams) *Arguments {
 								compoundArg.Value = ""
 								compoundArg.Type = FlagType
 								compoundArg.CmdLineIndex = arg.CmdLineIndex
+								compoundArg.CharOffset = j
 								compoundArg.ArgumentSpecification = compoundSpec
 								compoundArg.Flags = arg.Flags
 
@@ -541,10 +803,11 @@ func Parse(argv []string, params ParseParams) *Arguments {
 									s = compoundArg.ResolvedName
 									compoundArg.ResolvedName = res_nm
 									compoundArg.Value = value
+									compoundArg.alias_specification_ = compoundSpec
 
 									// Now need to look up the actual underlying specification
 
-									if actualFound, actualSpecification, _ := params.findSpecification(res_nm); actualFound {
+									if actualFound, actualSpecification, _ := finder.findSpecification(res_nm); actualFound {
 
 										compoundArg.ArgumentSpecification = actualSpecification
 									}
@@ -560,7 +823,13 @@ func Parse(argv []string, params ParseParams) *Arguments {
 
 						if validCompoundFlag {
 
-							args.Arguments = append(args.Arguments, compoundArguments...)
+							for _, compoundArg := range compoundArguments {
+
+								if err := emit(compoundArg); err != nil {
+
+									return err
+								}
+							}
 							continue
 						}
 					}
@@ -595,95 +864,30 @@ func Parse(argv []string, params ParseParams) *Arguments {
 				}
 			}
 
-			args.Arguments = append(args.Arguments, arg)
-		}
-	}
-
-	for _, arg := range args.Arguments {
-
-		switch arg.Type {
+			if stopAtFirstValue && !treatingAsValues && !stopped && ValueType == arg.Type {
 
-		case FlagType:
+				stopped = true
+				bounds.remainder_index = arg.CmdLineIndex
+			}
 
-			args.Flags = append(args.Flags, arg)
-		case OptionType:
+			if !nextIsOptValue {
 
-			args.Options = append(args.Options, arg)
-		case ValueType:
+				if err := emit(arg); err != nil {
 
-			args.Values = append(args.Values, arg)
+					return err
+				}
+			}
 		}
 	}
 
-	args.specifications = make([]*Specification, len(params.Specifications))
-
-	for i, spec := range params.Specifications {
-
-		var p *Specification = new(Specification)
-
-		*p = spec
-
-		args.specifications[i] = p
-	}
-
-	// now process the bit flags
-
-	{
-		for _, arg := range args.Flags {
-
-			spec := arg.ArgumentSpecification
-
-			if nil != spec {
-
-				if 0 != spec.BitFlags64 {
-
-					if nil != spec.flags64_receiver {
-
-						*spec.flags64_receiver |= spec.BitFlags64
-
-						if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & params.Flags) {
-
-							arg.Use()
-						}
-					}
-
-					args.bitFlags64 |= spec.BitFlags64
-				} else {
-					if 0 != spec.BitFlags {
-
-						if nil != spec.flags_receiver {
-
-							*spec.flags_receiver |= spec.BitFlags
-
-							if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & params.Flags) {
-
-								arg.Use()
-							}
-						}
-
-						args.bitFlags |= spec.BitFlags
-
-						if 0 == (Parse_DontMergeBitFlagsIntoBitFlags64 & params.Flags) {
-
-							if nil != spec.flags64_receiver {
+	// an option whose value is missing is still an option
 
-								*spec.flags64_receiver |= spec.BitFlags64
+	if nextIsOptValue {
 
-								if 0 == (Parse_DontMarkUsedDuringParseWhenMatchingBitFlags & params.Flags) {
-
-									arg.Use()
-								}
-							}
-
-							args.bitFlags64 |= int64(spec.BitFlags)
-						}
-					}
-				}
-			}
-		}
+		return emit(&scratch)
 	}
 
-	return args
+	return nil
 }
 
 // Obtains the combined bit-flags of all flag arguments with associated
@@ -851,6 +1055,58 @@ func (args *Arguments) LookupOption(id interface{}) (*Argument, bool) {
 	return nil, false
 }
 
+// Obtains the first flag - specified either as `string` or [Specification]
+// - observed during parsing, in the same manner as [Arguments.LookupFlag],
+// except that it is not marked used.
+func (args *Arguments) PeekFlag(id interface{}) (*Argument, bool) {
+
+	return peek_argument_named(args.Flags, argument_name_from_id(id, "PeekFlag", FlagType))
+}
+
+// Obtains the first option - specified either as `string` or
+// [Specification] - observed during parsing, in the same manner as
+// [Arguments.LookupOption], except that it is not marked used.
+func (args *Arguments) PeekOption(id interface{}) (*Argument, bool) {
+
+	return peek_argument_named(args.Options, argument_name_from_id(id, "PeekOption", OptionType))
+}
+
+// Obtains the arguments that follow the double hyphen - "--" - argument
+// (see [Arguments.DoubleHyphenIndex]), exactly as given, e.g. "cmd"
+// "--its-own-flag" from "mytool run -- cmd --its-own-flag", or `nil` if
+// there is no double hyphen.
+//
+// NOTE: If the double hyphen separates an option from its value - e.g.
+// "--verbosity -- chatty" - the value is included, though it is also the
+// value of the option.
+func (args *Arguments) Passthrough() []string {
+
+	if args.DoubleHyphenIndex < 1 || args.DoubleHyphenIndex >= len(args.Argv) {
+
+		return nil
+	}
+
+	return append([]string{}, args.Argv[args.DoubleHyphenIndex+1:]...)
+}
+
+// Obtains, verbatim, the arguments at which option processing stopped,
+// being either those that follow the double hyphen - "--" - argument or,
+// where [Parse_StopAtFirstValue] is in effect, the first value and all
+// arguments that follow it. If option processing did not stop, `nil` is
+// obtained.
+//
+// For example, given `myprog -v cmd -x -- y` and [Parse_StopAtFirstValue],
+// the remainder is `cmd -x -- y`.
+func (args *Arguments) Remainder() []string {
+
+	if args.remainder_index < 1 || args.remainder_index > len(args.Argv) {
+
+		return nil
+	}
+
+	return append([]string{}, args.Argv[args.remainder_index:]...)
+}
+
 // Obtains a sequence of all unused flag arguments.
 func (args *Arguments) GetUnusedFlags() []*Argument {
 
@@ -883,6 +1139,24 @@ func (args *Arguments) GetUnusedOptions() []*Argument {
 	return unused
 }
 
+// Obtains a sequence of all unused value arguments. Since values are never
+// marked used by [Arguments], this is all values unless the program has
+// marked those that it has processed (see [Argument.Use]).
+func (args *Arguments) GetUnusedValues() []*Argument {
+
+	var unused []*Argument
+
+	for _, v := range args.Values {
+
+		if v.isUnused() {
+
+			unused = append(unused, v)
+		}
+	}
+
+	return unused
+}
+
 // Obtains a sequence of all unused flag and option arguments.
 func (args *Arguments) GetUnusedFlagsAndOptions() []*Argument {
 
@@ -0,0 +1,235 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Flags that modify the command line reconstructed by [Arguments.ToArgv].
+type ToArgvFlag int
+
+// Defines options for reconstructing a command line (see
+// [Arguments.ToArgv]).
+type ToArgvOptions struct {
+	Flags ToArgvFlag
+	// If not empty, only those flag and option arguments matching (by
+	// name) one of these specifications are included.
+	Specifications []Specification
+}
+
+func (opts ToArgvOptions) String() string {
+
+	return fmt.Sprintf("<%T{ Flags=0x%x, Specifications=%v }>", opts, opts.Flags, opts.Specifications)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	ToArgv_None ToArgvFlag = 0
+)
+
+const (
+	ToArgv_IncludeProgramName   ToArgvFlag = 1 << iota // Causes the program argument - `argv[0]` - to be included, as given.
+	ToArgv_SeparateOptionValues                        // Causes option arguments to be given as separate name and value arguments, e.g. "--verbosity" "chatty", rather than as "--verbosity=chatty", where the option is specified.
+	ToArgv_UsedOnly                                    // Causes only flag and option arguments that have been used (see [Argument.Use]) to be included.
+	ToArgv_UnusedOnly                                  // Causes only flag and option arguments that have not been used (see [Argument.Use]) to be included.
+	ToArgv_ExcludeValues                               // Causes value arguments to be excluded.
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func (opts ToArgvOptions) includes(arg *Argument) bool {
+
+	if 0 != (ToArgv_UsedOnly&opts.Flags) && arg.isUnused() {
+
+		return false
+	}
+
+	if 0 != (ToArgv_UnusedOnly&opts.Flags) && !arg.isUnused() {
+
+		return false
+	}
+
+	if 0 != len(opts.Specifications) {
+
+		for _, specification := range opts.Specifications {
+
+			if specification.Name == arg.ResolvedName {
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func is_shell_safe(c rune) bool {
+
+	switch {
+
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+
+		return true
+	default:
+
+		return strings.ContainsRune("@%+=:,./_-", c)
+	}
+}
+
+// Obtains whether the given argument must be quoted for a POSIX shell.
+func needs_shell_quote(s string) bool {
+
+	return "" == s || -1 != strings.IndexFunc(s, func(c rune) bool { return !is_shell_safe(c) })
+}
+
+// Quotes, as necessary, the given argument for a POSIX shell.
+func shell_quote(s string) string {
+
+	if !needs_shell_quote(s) {
+
+		return s
+	}
+
+	return "'" + shell_escape(s) + "'"
+}
+
+// Escapes any single-quote in the given (part of an) argument, so that it
+// may appear within single quotes.
+func shell_escape(s string) string {
+
+	return strings.ReplaceAll(s, "'", `'\''`)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Reconstructs a command line from the parsed arguments, in canonical
+// form, such that parsing it with the same specifications gives the same
+// flags, options, and values:
+//
+//   - flags are given by their resolved names, e.g. "-v" as "--verbose",
+//     and compound flags separately, e.g. "-dq" as "--debug" "--quiet";
+//   - options are given by their resolved names and values, e.g.
+//     "--verbosity=chatty", or, if [ToArgv_SeparateOptionValues] is
+//     specified and the option is specified, "--verbosity" "chatty";
+//     option-value aliases are given as the option, e.g. "-c" as
+//     "--verbosity=chatty";
+//   - flags and options are followed by values, in command-line order,
+//     with a double hyphen - "--" - preceding the first value that begins
+//     with a hyphen, such that it, and all those following, are not
+//     mistaken for flags or options.
+//
+// The parse flags with which the arguments were parsed are respected, such
+// that parsing the command line with the same flags gives the same values:
+// where [Parse_StopAtFirstValue] is in effect, only the first value is
+// preceded by a double hyphen, if it begins with a hyphen, since all those
+// following it are values regardless of their form; and where
+// [Parse_DontRecogniseDoubleHyphenToStartValues] is specified, no double
+// hyphen is given, since it would not be recognised.
+//
+// Flags and options may be selected by use and by specification, and
+// values excluded, according to opts.
+func (args *Arguments) ToArgv(opts ToArgvOptions) []string {
+
+	var argv []string
+
+	if 0 != (ToArgv_IncludeProgramName&opts.Flags) && 0 != len(args.Argv) {
+
+		argv = append(argv, args.Argv[0])
+	}
+
+	for _, arg := range args.Arguments {
+
+		switch arg.Type {
+
+		case FlagType:
+
+			if opts.includes(arg) {
+
+				argv = append(argv, arg.ResolvedName)
+			}
+		case OptionType:
+
+			if opts.includes(arg) {
+
+				// an unspecified option is recognised only as name=value
+
+				if 0 != (ToArgv_SeparateOptionValues&opts.Flags) && nil != arg.ArgumentSpecification && OptionType == arg.ArgumentSpecification.Type {
+
+					argv = append(argv, arg.ResolvedName, arg.Value)
+				} else {
+
+					argv = append(argv, arg.ResolvedName+"="+arg.Value)
+				}
+			}
+		}
+	}
+
+	if 0 == (ToArgv_ExcludeValues & opts.Flags) {
+
+		// whether a double hyphen has been given, or is not to be given
+
+		double_hyphen := 0 != (Parse_DontRecogniseDoubleHyphenToStartValues & args.parse_flags)
+
+		for _, arg := range args.Values {
+
+			if !double_hyphen && strings.HasPrefix(arg.Value, "-") {
+
+				double_hyphen = true
+
+				argv = append(argv, "--")
+			}
+
+			argv = append(argv, arg.Value)
+
+			if 0 != (Parse_StopAtFirstValue & args.parse_flags) {
+
+				// all that follow the first value are values
+
+				double_hyphen = true
+			}
+		}
+	}
+
+	return argv
+}
+
+// Renders the given arguments as a command line for a POSIX shell, for
+// example for logging, such that tokenizing it by the shell gives the
+// same arguments. Each argument that contains characters other than
+// letters, digits, and any of "@%+=:,./_-", or is empty, is single-quoted.
+func ShellQuote(argv []string) string {
+
+	quoted := make([]string, len(argv))
+
+	for i, s := range argv {
+
+		quoted[i] = shell_quote(s)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,176 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func argv_params() clasp.ParseParams {
+
+	return clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--debug").SetAlias("-d"),
+			clasp.Flag("--quiet").SetAlias("-q"),
+			clasp.Option("--verbosity").SetAlias("-V").SetValues("terse", "chatty"),
+			clasp.AliasesFor("--verbosity=chatty", "-c"),
+		},
+	}
+}
+
+// Summarises the flags and options, and then the values, which is the
+// order in which they are reconstructed.
+func summarise_(args *clasp.Arguments) []string {
+
+	var summary []string
+
+	for _, arg := range args.Arguments {
+
+		if clasp.ValueType != arg.Type {
+
+			summary = append(summary, arg.Type.String()+":"+arg.ResolvedName+":"+arg.Value)
+		}
+	}
+
+	for _, arg := range args.Values {
+
+		summary = append(summary, "Value:"+arg.Value)
+	}
+
+	return summary
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ToArgv_canonical(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "file1", "-dq", "-V", "terse", "--unknown=x", "-c", "--", "-file2", "file3"}, argv_params())
+
+	require.Equal(t, []string{"--debug", "--quiet", "--verbosity=terse", "--unknown=x", "--verbosity=chatty", "file1", "--", "-file2", "file3"}, args.ToArgv(clasp.ToArgvOptions{}))
+
+	require.Equal(t, []string{"myprog", "--debug", "--quiet", "--verbosity", "terse", "--unknown=x", "--verbosity", "chatty"}, args.ToArgv(clasp.ToArgvOptions{Flags: clasp.ToArgv_IncludeProgramName | clasp.ToArgv_SeparateOptionValues | clasp.ToArgv_ExcludeValues}))
+}
+
+func Test_ToArgv_round_trips(t *testing.T) {
+
+	params := argv_params()
+
+	for _, argv := range [][]string{
+
+		{"myprog"},
+		{"myprog", "-dq", "--verbosity", "-x", "value"},
+		{"myprog", "-c", "--", "-", "--debug", "value"},
+		{"myprog", "--unknown", "value=1", "--opt=a=b"},
+	} {
+
+		args := clasp.Parse(argv, params)
+
+		for _, flags := range []clasp.ToArgvFlag{clasp.ToArgv_None, clasp.ToArgv_SeparateOptionValues} {
+
+			reconstructed := append([]string{"myprog"}, args.ToArgv(clasp.ToArgvOptions{Flags: flags})...)
+
+			require.Equal(t, summarise_(args), summarise_(clasp.Parse(reconstructed, params)), "for %v", argv)
+		}
+	}
+}
+
+func Test_ToArgv_round_trips_StopAtFirstValue(t *testing.T) {
+
+	params := argv_params()
+
+	params.Flags = clasp.Parse_StopAtFirstValue
+
+	for _, test := range []struct {
+		argv     []string
+		expected []string
+	}{
+		{[]string{"myprog", "-d", "cmd", "-x", "--", "y"}, []string{"--debug", "cmd", "-x", "--", "y"}},
+		{[]string{"myprog", "-d", "--", "-x", "--", "y"}, []string{"--debug", "--", "-x", "--", "y"}},
+		{[]string{"myprog", "-c", "cmd", "-q"}, []string{"--verbosity=chatty", "cmd", "-q"}},
+	} {
+
+		args := clasp.Parse(test.argv, params)
+
+		reconstructed := args.ToArgv(clasp.ToArgvOptions{})
+
+		require.Equal(t, test.expected, reconstructed, "for %v", test.argv)
+		require.Equal(t, summarise_(args), summarise_(clasp.Parse(append([]string{"myprog"}, reconstructed...), params)), "for %v", test.argv)
+	}
+}
+
+func Test_ToArgv_round_trips_DontRecogniseDoubleHyphenToStartValues(t *testing.T) {
+
+	params := argv_params()
+
+	params.Flags = clasp.Parse_DontRecogniseDoubleHyphenToStartValues | clasp.ParseTreatSingleHyphenAsValue
+
+	for _, test := range []struct {
+		argv     []string
+		expected []string
+	}{
+		{[]string{"myprog", "-d", "value", "-", "-q"}, []string{"--debug", "--quiet", "value", "-"}},
+		{[]string{"myprog", "--", "-", "value"}, []string{"--", "-", "value"}},
+	} {
+
+		args := clasp.Parse(test.argv, params)
+
+		reconstructed := args.ToArgv(clasp.ToArgvOptions{})
+
+		require.Equal(t, test.expected, reconstructed, "for %v", test.argv)
+		require.Equal(t, summarise_(args), summarise_(clasp.Parse(append([]string{"myprog"}, reconstructed...), params)), "for %v", test.argv)
+	}
+}
+
+func Test_ToArgv_filters(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "-d", "-q", "--verbosity=terse", "--unknown", "value"}, argv_params())
+
+	args.FlagIsSpecified("--debug")
+
+	require.Equal(t, []string{"--debug"}, args.ToArgv(clasp.ToArgvOptions{Flags: clasp.ToArgv_UsedOnly | clasp.ToArgv_ExcludeValues}))
+	require.Equal(t, []string{"--quiet", "--verbosity=terse", "--unknown", "value"}, args.ToArgv(clasp.ToArgvOptions{Flags: clasp.ToArgv_UnusedOnly}))
+	require.Equal(t, []string{"--quiet", "--verbosity=terse", "value"}, args.ToArgv(clasp.ToArgvOptions{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--quiet"),
+			clasp.Option("--verbosity"),
+		},
+	}))
+}
+
+func Test_ShellQuote(t *testing.T) {
+
+	require.Equal(t, "", clasp.ShellQuote(nil))
+	require.Equal(t, "myprog --verbosity=chatty -- -file ./a/b.txt", clasp.ShellQuote([]string{"myprog", "--verbosity=chatty", "--", "-file", "./a/b.txt"}))
+	require.Equal(t, `'' 'a b' 'it'\''s' '$HOME' '*'`, clasp.ShellQuote([]string{"", "a b", "it's", "$HOME", "*"}))
+}
+
+func Test_ShellQuote_round_trips_through_shell(t *testing.T) {
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+
+		t.Skip("no shell available")
+	}
+
+	argv := []string{"plain", "", "with space", "it's", `"double"`, "$HOME", "`cmd`", "a\\b", "tab\there", "new\nline", "*?[x]", "!", "~user", "#", "ünïcödé"}
+
+	out, err := exec.Command(sh, "-c", `printf '%s\0' `+clasp.ShellQuote(argv)).Output()
+
+	require.Nil(t, err)
+	require.Equal(t, argv, strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00"))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,416 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasptest
+
+import (
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Bitmask type that specifies which fields of an [ExpectedArgument], in
+// addition to its type, are compared.
+type ArgumentField int
+
+// Structure that defines an expected argument, which matches an actual
+// argument (see [ArgumentMatches]) if their types are equal, as are each
+// of the fields specified in Fields, whether or not they are zero: e.g.
+// an expected option with [Field_Value] and an empty value matches only
+// an option with an empty value, such as "--name=".
+//
+// Expected arguments are usually obtained from [FlagArg], [OptionArg],
+// and [ValueArg], whose fields may be extended by the builder methods,
+// e.g. `clasptest.FlagArg("--verbose").WithGivenName("-v")`.
+type ExpectedArgument struct {
+	Argument clasp.Argument // The type and the values of the compared fields.
+	Fields   ArgumentField  // The fields, in addition to the type, that are compared.
+}
+
+// Structure that defines the expected flags, options, and values of parsed
+// arguments (see [AssertParsed]), each element of which is matched as
+// described for [ArgumentMatches]. A `nil` (or empty) slice expects that
+// there are no arguments of that type.
+type ExpectedArguments struct {
+	Flags   []ExpectedArgument
+	Options []ExpectedArgument
+	Values  []ExpectedArgument
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	Field_ResolvedName    ArgumentField = 1 << iota // Compares [clasp.Argument.ResolvedName].
+	Field_GivenName                                 // Compares [clasp.Argument.GivenName].
+	Field_Value                                     // Compares [clasp.Argument.Value].
+	Field_CmdLineIndex                              // Compares [clasp.Argument.CmdLineIndex].
+	Field_NumGivenHyphens                           // Compares [clasp.Argument.NumGivenHyphens].
+	Field_Flags                                     // Compares [clasp.Argument.Flags].
+
+	Field_None ArgumentField = 0 // Compares no fields other than the type.
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Obtains the fields of the given argument that are not zero.
+func nonzero_fields(arg *clasp.Argument) (fields ArgumentField) {
+
+	if "" != arg.ResolvedName {
+
+		fields |= Field_ResolvedName
+	}
+
+	if "" != arg.GivenName {
+
+		fields |= Field_GivenName
+	}
+
+	if "" != arg.Value {
+
+		fields |= Field_Value
+	}
+
+	if 0 != arg.CmdLineIndex {
+
+		fields |= Field_CmdLineIndex
+	}
+
+	if 0 != arg.NumGivenHyphens {
+
+		fields |= Field_NumGivenHyphens
+	}
+
+	if 0 != arg.Flags {
+
+		fields |= Field_Flags
+	}
+
+	return
+}
+
+// Obtains the names of the fields in which actual does not match expected.
+func mismatched_fields(expected ExpectedArgument, actual *clasp.Argument) (fields []string) {
+
+	e := &expected.Argument
+
+	if e.Type != actual.Type {
+
+		fields = append(fields, "Type")
+	}
+
+	if 0 != (Field_ResolvedName&expected.Fields) && e.ResolvedName != actual.ResolvedName {
+
+		fields = append(fields, "ResolvedName")
+	}
+
+	if 0 != (Field_GivenName&expected.Fields) && e.GivenName != actual.GivenName {
+
+		fields = append(fields, "GivenName")
+	}
+
+	if 0 != (Field_Value&expected.Fields) && e.Value != actual.Value {
+
+		fields = append(fields, "Value")
+	}
+
+	if 0 != (Field_CmdLineIndex&expected.Fields) && e.CmdLineIndex != actual.CmdLineIndex {
+
+		fields = append(fields, "CmdLineIndex")
+	}
+
+	if 0 != (Field_NumGivenHyphens&expected.Fields) && e.NumGivenHyphens != actual.NumGivenHyphens {
+
+		fields = append(fields, "NumGivenHyphens")
+	}
+
+	if 0 != (Field_Flags&expected.Fields) && e.Flags != actual.Flags {
+
+		fields = append(fields, "Flags")
+	}
+
+	return
+}
+
+// Describes the given argument by its type and the given fields.
+func describe_argument(arg *clasp.Argument, fields ArgumentField) string {
+
+	parts := []string{fmt.Sprintf("Type=%v", arg.Type)}
+
+	if 0 != (Field_ResolvedName & fields) {
+
+		parts = append(parts, fmt.Sprintf("ResolvedName=%q", arg.ResolvedName))
+	}
+
+	if 0 != (Field_GivenName & fields) {
+
+		parts = append(parts, fmt.Sprintf("GivenName=%q", arg.GivenName))
+	}
+
+	if 0 != (Field_Value & fields) {
+
+		parts = append(parts, fmt.Sprintf("Value=%q", arg.Value))
+	}
+
+	if 0 != (Field_CmdLineIndex & fields) {
+
+		parts = append(parts, fmt.Sprintf("CmdLineIndex=%d", arg.CmdLineIndex))
+	}
+
+	if 0 != (Field_NumGivenHyphens & fields) {
+
+		parts = append(parts, fmt.Sprintf("NumGivenHyphens=%d", arg.NumGivenHyphens))
+	}
+
+	if 0 != (Field_Flags & fields) {
+
+		parts = append(parts, fmt.Sprintf("Flags=0x%x", arg.Flags))
+	}
+
+	return "{ " + strings.Join(parts, ", ") + " }"
+}
+
+func describe_expected(expected ExpectedArgument) string {
+
+	return describe_argument(&expected.Argument, expected.Fields)
+}
+
+// Describes the given actual argument by its fields that are not zero and
+// those that are compared by the given fields.
+func describe_actual(actual *clasp.Argument, fields ArgumentField) string {
+
+	return describe_argument(actual, nonzero_fields(actual)|fields)
+}
+
+// Obtains a report of the differences between the expected and actual
+// arguments, or the empty string if they match.
+func arguments_diff(expected []ExpectedArgument, actual []*clasp.Argument) string {
+
+	var sb strings.Builder
+
+	matched := len(expected) == len(actual)
+
+	for i := 0; i < max(len(expected), len(actual)); i++ {
+
+		switch {
+
+		case i >= len(actual):
+
+			fmt.Fprintf(&sb, "  [%d] missing   expected %s\n", i, describe_expected(expected[i]))
+
+			matched = false
+		case i >= len(expected):
+
+			fmt.Fprintf(&sb, "  [%d] extra     actual   %s\n", i, describe_actual(actual[i], Field_None))
+
+			matched = false
+		default:
+
+			if fields := mismatched_fields(expected[i], actual[i]); 0 != len(fields) {
+
+				fmt.Fprintf(&sb, "  [%d] mismatch  expected %s\n", i, describe_expected(expected[i]))
+				fmt.Fprintf(&sb, "                actual   %s\n", describe_actual(actual[i], expected[i].Fields))
+				fmt.Fprintf(&sb, "                differs in %s\n", strings.Join(fields, ", "))
+
+				matched = false
+			} else {
+
+				fmt.Fprintf(&sb, "  [%d] ok        %s\n", i, describe_expected(expected[i]))
+			}
+		}
+	}
+
+	if matched {
+
+		return ""
+	}
+
+	return sb.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains an expected flag argument (see [ExpectedArgument]) of the given
+// resolved name.
+func FlagArg(resolvedName string) ExpectedArgument {
+
+	return ExpectedArgument{
+
+		Argument: clasp.Argument{Type: clasp.FlagType, ResolvedName: resolvedName},
+		Fields:   Field_ResolvedName,
+	}
+}
+
+// Obtains an expected option argument (see [ExpectedArgument]) of the
+// given resolved name and value, which may be empty.
+func OptionArg(resolvedName, value string) ExpectedArgument {
+
+	return ExpectedArgument{
+
+		Argument: clasp.Argument{Type: clasp.OptionType, ResolvedName: resolvedName, Value: value},
+		Fields:   Field_ResolvedName | Field_Value,
+	}
+}
+
+// Obtains an expected value argument (see [ExpectedArgument]) of the
+// given value, which may be empty.
+func ValueArg(value string) ExpectedArgument {
+
+	return ExpectedArgument{
+
+		Argument: clasp.Argument{Type: clasp.ValueType, Value: value},
+		Fields:   Field_Value,
+	}
+}
+
+// Builder method that causes the resolved name to be compared.
+func (expected ExpectedArgument) WithResolvedName(resolvedName string) ExpectedArgument {
+
+	expected.Argument.ResolvedName = resolvedName
+	expected.Fields |= Field_ResolvedName
+
+	return expected
+}
+
+// Builder method that causes the given name to be compared.
+func (expected ExpectedArgument) WithGivenName(givenName string) ExpectedArgument {
+
+	expected.Argument.GivenName = givenName
+	expected.Fields |= Field_GivenName
+
+	return expected
+}
+
+// Builder method that causes the value to be compared.
+func (expected ExpectedArgument) WithValue(value string) ExpectedArgument {
+
+	expected.Argument.Value = value
+	expected.Fields |= Field_Value
+
+	return expected
+}
+
+// Builder method that causes the command-line index to be compared.
+func (expected ExpectedArgument) WithCmdLineIndex(cmdLineIndex int) ExpectedArgument {
+
+	expected.Argument.CmdLineIndex = cmdLineIndex
+	expected.Fields |= Field_CmdLineIndex
+
+	return expected
+}
+
+// Builder method that causes the number of given hyphens to be compared.
+func (expected ExpectedArgument) WithNumGivenHyphens(numGivenHyphens int) ExpectedArgument {
+
+	expected.Argument.NumGivenHyphens = numGivenHyphens
+	expected.Fields |= Field_NumGivenHyphens
+
+	return expected
+}
+
+// Builder method that causes the flags to be compared.
+func (expected ExpectedArgument) WithFlags(flags int) ExpectedArgument {
+
+	expected.Argument.Flags = flags
+	expected.Fields |= Field_Flags
+
+	return expected
+}
+
+// Indicates whether actual matches expected, in that their types are
+// equal, as are each of the fields specified by expected (see
+// [ExpectedArgument]).
+func ArgumentMatches(expected ExpectedArgument, actual *clasp.Argument) bool {
+
+	return 0 == len(mismatched_fields(expected, actual))
+}
+
+// Asserts that the actual arguments match (see [ArgumentMatches]) the
+// expected arguments, in order, reporting each missing, extra, and
+// mismatched argument if not.
+func AssertArguments(t testing.TB, expected []ExpectedArgument, actual []*clasp.Argument) bool {
+
+	t.Helper()
+
+	if diff := arguments_diff(expected, actual); "" != diff {
+
+		t.Errorf("arguments differ (expected %d; actual %d):\n%s", len(expected), len(actual), diff)
+
+		return false
+	}
+
+	return true
+}
+
+// Asserts that the flags, options, and values of the given parsed
+// arguments match those expected, reporting the differences of each that
+// does not, and that a `nil` args is not given.
+//
+// For example:
+//
+//	args := clasp.Parse(argv, params)
+//
+//	clasptest.AssertParsed(t, args, clasptest.ExpectedArguments{
+//
+//		Flags:   []clasptest.ExpectedArgument{clasptest.FlagArg("--verbose")},
+//		Options: []clasptest.ExpectedArgument{clasptest.OptionArg("--level", "3")},
+//		Values:  []clasptest.ExpectedArgument{clasptest.ValueArg("in.txt")},
+//	})
+func AssertParsed(t testing.TB, args *clasp.Arguments, expected ExpectedArguments) bool {
+
+	t.Helper()
+
+	if nil == args {
+
+		t.Errorf("parsed arguments are nil")
+
+		return false
+	}
+
+	var sb strings.Builder
+
+	for _, kind := range []struct {
+		name     string
+		expected []ExpectedArgument
+		actual   []*clasp.Argument
+	}{
+		{"flags", expected.Flags, args.Flags},
+		{"options", expected.Options, args.Options},
+		{"values", expected.Values, args.Values},
+	} {
+
+		if diff := arguments_diff(kind.expected, kind.actual); "" != diff {
+
+			fmt.Fprintf(&sb, "%s differ (expected %d; actual %d):\n%s", kind.name, len(kind.expected), len(kind.actual), diff)
+		}
+	}
+
+	if 0 != sb.Len() {
+
+		t.Errorf("parsed arguments of %q differ from those expected:\n%s", args.Argv, sb.String())
+
+		return false
+	}
+
+	return true
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
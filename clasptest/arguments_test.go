@@ -0,0 +1,140 @@
+package clasptest_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	"github.com/synesissoftware/CLASP.Go/clasptest"
+
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func parse_(argv ...string) *clasp.Arguments {
+
+	return clasp.Parse(append([]string{"myprog"}, argv...), clasp.ParseParams{
+
+		Specifications: usage_specifications(),
+	})
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ArgumentMatches(t *testing.T) {
+
+	actual := &clasp.Argument{Type: clasp.FlagType, ResolvedName: "--verbose", GivenName: "-v", CmdLineIndex: 2, NumGivenHyphens: 1}
+
+	require.True(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose"), actual))
+	require.True(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose").WithGivenName("-v").WithCmdLineIndex(2), actual))
+	require.True(t, clasptest.ArgumentMatches(clasptest.ExpectedArgument{Argument: clasp.Argument{Type: clasp.FlagType}}, actual))
+
+	require.False(t, clasptest.ArgumentMatches(clasptest.FlagArg("--quiet"), actual))
+	require.False(t, clasptest.ArgumentMatches(clasptest.OptionArg("--verbose", ""), actual))
+	require.False(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose").WithCmdLineIndex(1), actual))
+	require.False(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose").WithFlags(0x1), actual))
+}
+
+func Test_ArgumentMatches_empty_fields(t *testing.T) {
+
+	args := parse_("--level=", "--level=3", "--", "")
+
+	// an empty value is asserted, rather than being a wildcard
+
+	require.True(t, clasptest.ArgumentMatches(clasptest.OptionArg("--level", ""), args.Options[0]))
+	require.False(t, clasptest.ArgumentMatches(clasptest.OptionArg("--level", ""), args.Options[1]))
+
+	require.True(t, clasptest.ArgumentMatches(clasptest.ValueArg(""), args.Values[0]))
+	require.False(t, clasptest.ArgumentMatches(clasptest.ValueArg("x"), args.Values[0]))
+
+	// a flag has no value, which may be asserted
+
+	require.True(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose").WithValue(""), parse_("-v").Flags[0]))
+	require.False(t, clasptest.ArgumentMatches(clasptest.FlagArg("--verbose").WithGivenName(""), parse_("-v").Flags[0]))
+
+	rtb := new(recording_tb)
+
+	require.False(t, clasptest.AssertArguments(rtb, []clasptest.ExpectedArgument{clasptest.OptionArg("--level", ""), clasptest.OptionArg("--level", "")}, args.Options))
+	require.Equal(t, []string{`arguments differ (expected 2; actual 2):
+  [0] ok        { Type=Option, ResolvedName="--level", Value="" }
+  [1] mismatch  expected { Type=Option, ResolvedName="--level", Value="" }
+                actual   { Type=Option, ResolvedName="--level", GivenName="--level", Value="3", CmdLineIndex=2, NumGivenHyphens=2 }
+                differs in Value
+`}, rtb.errors)
+}
+
+func Test_AssertParsed(t *testing.T) {
+
+	tests := []struct {
+		argv     []string
+		expected clasptest.ExpectedArguments
+	}{
+		{
+			argv: []string{},
+		},
+		{
+			argv: []string{"-v", "--level=3", "in.txt", "out.txt"},
+			expected: clasptest.ExpectedArguments{
+
+				Flags:   []clasptest.ExpectedArgument{clasptest.FlagArg("--verbose")},
+				Options: []clasptest.ExpectedArgument{clasptest.OptionArg("--level", "3")},
+				Values:  []clasptest.ExpectedArgument{clasptest.ValueArg("in.txt"), clasptest.ValueArg("out.txt")},
+			},
+		},
+		{
+			argv: []string{"--level", "3", "--", "-v"},
+			expected: clasptest.ExpectedArguments{
+
+				Options: []clasptest.ExpectedArgument{clasptest.OptionArg("--level", "3").WithCmdLineIndex(1)},
+				Values:  []clasptest.ExpectedArgument{clasptest.ValueArg("-v").WithCmdLineIndex(4)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		require.True(t, clasptest.AssertParsed(t, parse_(test.argv...), test.expected))
+	}
+}
+
+func Test_AssertParsed_reports_differences(t *testing.T) {
+
+	rtb := new(recording_tb)
+
+	require.False(t, clasptest.AssertParsed(rtb, parse_("-v", "--help", "--level=3"), clasptest.ExpectedArguments{
+
+		Flags:   []clasptest.ExpectedArgument{clasptest.FlagArg("--verbose"), clasptest.FlagArg("--quiet")},
+		Options: []clasptest.ExpectedArgument{clasptest.OptionArg("--level", "3")},
+		Values:  []clasptest.ExpectedArgument{clasptest.ValueArg("in.txt")},
+	}))
+
+	require.Equal(t, []string{`parsed arguments of ["myprog" "-v" "--help" "--level=3"] differ from those expected:
+flags differ (expected 2; actual 2):
+  [0] ok        { Type=Flag, ResolvedName="--verbose" }
+  [1] mismatch  expected { Type=Flag, ResolvedName="--quiet" }
+                actual   { Type=Flag, ResolvedName="--help", GivenName="--help", CmdLineIndex=2, NumGivenHyphens=2 }
+                differs in ResolvedName
+values differ (expected 1; actual 0):
+  [0] missing   expected { Type=Value, Value="in.txt" }
+`}, rtb.errors)
+}
+
+func Test_AssertArguments(t *testing.T) {
+
+	args := parse_("a", "b")
+
+	require.True(t, clasptest.AssertArguments(t, []clasptest.ExpectedArgument{clasptest.ValueArg("a"), clasptest.ValueArg("b")}, args.Values))
+
+	rtb := new(recording_tb)
+
+	require.False(t, clasptest.AssertArguments(rtb, []clasptest.ExpectedArgument{clasptest.ValueArg("a")}, args.Values))
+	require.Equal(t, []string{`arguments differ (expected 1; actual 2):
+  [0] ok        { Type=Value, Value="a" }
+  [1] extra     actual   { Type=Value, Value="b", CmdLineIndex=2 }
+`}, rtb.errors)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,105 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+// Package clasptest provides utilities for testing programs that use
+// CLASP: a recording [clasp.Exiter], helpers that run [clasp.ShowUsage] and
+// [clasp.ShowVersion] and obtain their output and exit code, golden-file
+// comparison, and assertions for parsed arguments.
+package clasptest
+
+import (
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"bytes"
+	"fmt"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Exiter (see [clasp.Exiter]) that records, rather than effects, exiting.
+type RecordingExiter struct {
+	ExitCode int  // The exit code of the last call to Exit.
+	Exited   bool // Indicates whether Exit has been called.
+	NumCalls int  // The number of calls to Exit.
+}
+
+// Records the exit code.
+func (re *RecordingExiter) Exit(exitCode int) {
+
+	re.ExitCode = exitCode
+	re.Exited = true
+	re.NumCalls++
+}
+
+func (re RecordingExiter) String() string {
+
+	return fmt.Sprintf("<%T{ ExitCode=%d, Exited=%t, NumCalls=%d }>", re, re.ExitCode, re.Exited, re.NumCalls)
+}
+
+// Structure that defines the result of running [clasp.ShowUsage] or
+// [clasp.ShowVersion] (see [RunShowUsage] and [RunShowVersion]).
+type Result struct {
+	Output   string // The output written to the stream.
+	ExitCode int    // The exit code passed to the exiter if it was called, or otherwise that returned.
+	Exited   bool   // Indicates whether the exiter was called.
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func run_(fn func([]clasp.Specification, clasp.UsageParams) (int, error), specifications []clasp.Specification, params clasp.UsageParams) (Result, error) {
+
+	var buf bytes.Buffer
+	var exiter RecordingExiter
+
+	params.Stream = &buf
+	params.Exiter = &exiter
+
+	rc, err := fn(specifications, params)
+
+	result := Result{
+
+		Output:   buf.String(),
+		ExitCode: rc,
+		Exited:   exiter.Exited,
+	}
+
+	if exiter.Exited {
+
+		result.ExitCode = exiter.ExitCode
+	}
+
+	return result, err
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Runs [clasp.ShowUsage] with the given specifications and parameters,
+// save that the stream and exiter are replaced, and obtains the output and
+// exit code, along with any error that it returns.
+func RunShowUsage(specifications []clasp.Specification, params clasp.UsageParams) (Result, error) {
+
+	return run_(clasp.ShowUsage, specifications, params)
+}
+
+// Runs [clasp.ShowVersion] with the given specifications and parameters,
+// save that the stream and exiter are replaced, and obtains the output and
+// exit code, along with any error that it returns.
+func RunShowVersion(specifications []clasp.Specification, params clasp.UsageParams) (Result, error) {
+
+	return run_(clasp.ShowVersion, specifications, params)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
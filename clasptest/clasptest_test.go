@@ -0,0 +1,128 @@
+package clasptest_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	"github.com/synesissoftware/CLASP.Go/clasptest"
+
+	"fmt"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+// Records, rather than reports, errors, so that failing assertions may be
+// tested.
+type recording_tb struct {
+	testing.TB
+
+	errors []string
+}
+
+func (rtb *recording_tb) Helper() {
+}
+
+func (rtb *recording_tb) Errorf(format string, args ...interface{}) {
+
+	rtb.errors = append(rtb.errors, fmt.Sprintf(format, args...))
+}
+
+func usage_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--level").SetHelp("Specifies the level"),
+		clasp.HelpFlag(),
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_RecordingExiter(t *testing.T) {
+
+	var exiter clasptest.RecordingExiter
+
+	require.False(t, exiter.Exited)
+
+	exiter.Exit(2)
+	exiter.Exit(3)
+
+	require.True(t, exiter.Exited)
+	require.Equal(t, 3, exiter.ExitCode)
+	require.Equal(t, 2, exiter.NumCalls)
+}
+
+func Test_RunShowUsage(t *testing.T) {
+
+	result, err := clasptest.RunShowUsage(usage_specifications(), clasp.UsageParams{
+
+		ProgramName: "myprog",
+		UsageFlags:  clasp.SkipBlanksBetweenLines,
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.Exited)
+	require.Equal(t, 0, result.ExitCode)
+	require.Equal(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+	-v
+	--verbose
+		Makes output verbose
+	--level=<value>
+		Specifies the level
+	--help
+		Shows this help and exits
+`, result.Output)
+
+	result, err = clasptest.RunShowUsage(nil, clasp.UsageParams{
+
+		ProgramName: "myprog",
+		ExitCode:    2,
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.Exited)
+	require.Equal(t, 2, result.ExitCode)
+	require.Equal(t, "USAGE: myprog\n", result.Output)
+
+	result, err = clasptest.RunShowUsage(nil, clasp.UsageParams{
+
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit,
+		ExitCode:    2,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.Exited)
+	require.Equal(t, 2, result.ExitCode)
+}
+
+func Test_RunShowVersion(t *testing.T) {
+
+	result, err := clasptest.RunShowVersion(nil, clasp.UsageParams{
+
+		ProgramName: "myprog",
+		Version:     "1.2.3",
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.Exited)
+	require.Equal(t, 0, result.ExitCode)
+	require.Equal(t, "myprog 1.2.3\n", result.Output)
+
+	_, err = clasptest.RunShowVersion(nil, clasp.UsageParams{
+
+		ProgramName: "myprog",
+		Version:     3.14,
+	})
+
+	require.Error(t, err)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,220 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasptest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// The name of the command-line flag - e.g. `go test ./... -update` -
+// that, if defined by the test program (see [Update]), causes
+// [AssertGolden] to update, rather than compare with, golden files.
+const UpdateFlagName = "update"
+
+// The name of the environment variable - e.g.
+// `CLASPTEST_UPDATE=1 go test ./...` - that, if set to a true value (as
+// understood by [strconv.ParseBool]), causes [AssertGolden] to update,
+// rather than compare with, golden files.
+const UpdateEnvVarName = "CLASPTEST_UPDATE"
+
+/* /////////////////////////////////////////////////////////////////////////
+ * variables
+ */
+
+// If non-nil, determines whether [AssertGolden] updates, rather than
+// compares with, golden files, taking precedence over the flag named
+// [UpdateFlagName] and the environment variable named [UpdateEnvVarName].
+//
+// This package does not itself define any flag, so a test program that
+// wishes to support `-update` defines it, either as
+//
+//	var update = flag.Bool(clasptest.UpdateFlagName, false, "update golden files")
+//
+// which is found when comparing, or as any other flag, in which case it
+// assigns this variable, as in
+//
+//	func init() {
+//
+//		clasptest.Update = flag.Bool("regen", false, "regenerate golden files")
+//	}
+var Update *bool
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func updating_golden_files() bool {
+
+	if nil != Update {
+
+		return *Update
+	}
+
+	if f := flag.Lookup(UpdateFlagName); nil != f {
+
+		if b, err := strconv.ParseBool(f.Value.String()); err == nil && b {
+
+			return true
+		}
+	}
+
+	b, err := strconv.ParseBool(os.Getenv(UpdateEnvVarName))
+
+	return err == nil && b
+}
+
+// Obtains the instruction to update golden files, according to the means
+// by which updating may be requested, given the precedence described for
+// [Update].
+func update_instruction() string {
+
+	switch {
+
+	case nil != Update:
+
+		return "set clasptest.Update"
+	case nil != flag.Lookup(UpdateFlagName):
+
+		return fmt.Sprintf("run the test with -%s or %s=1", UpdateFlagName, UpdateEnvVarName)
+	default:
+
+		return fmt.Sprintf("run the test with %s=1", UpdateEnvVarName)
+	}
+}
+
+// Obtains a line-by-line difference between expected and actual, in which
+// each line is prefixed by "  " if common to both, "- " if only in
+// expected, and "+ " if only in actual.
+func line_diff(expected, actual string) string {
+
+	e := strings.Split(expected, "\n")
+	a := strings.Split(actual, "\n")
+
+	// lengths of the longest common subsequences of the suffixes
+
+	lcs := make([][]int, len(e)+1)
+	for i := range lcs {
+
+		lcs[i] = make([]int, len(a)+1)
+	}
+
+	for i := len(e) - 1; i >= 0; i-- {
+
+		for j := len(a) - 1; j >= 0; j-- {
+
+			if e[i] == a[j] {
+
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+
+	i, j := 0, 0
+
+	for i < len(e) || j < len(a) {
+
+		switch {
+
+		case i < len(e) && j < len(a) && e[i] == a[j]:
+
+			sb.WriteString("  " + e[i] + "\n")
+			i++
+			j++
+		case i < len(e) && (j == len(a) || lcs[i+1][j] >= lcs[i][j+1]):
+
+			sb.WriteString("- " + e[i] + "\n")
+			i++
+		default:
+
+			sb.WriteString("+ " + a[j] + "\n")
+			j++
+		}
+	}
+
+	return sb.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Asserts that actual is equal to the contents of the golden file at the
+// given path - conventionally "testdata/<name>.golden" - reporting a
+// line-by-line difference if not.
+//
+// If updating is requested (see [Update], [UpdateFlagName], and
+// [UpdateEnvVarName]), the golden file - and any missing directories - is
+// instead written with actual.
+func AssertGolden(t testing.TB, path string, actual string) bool {
+
+	t.Helper()
+
+	if updating_golden_files() {
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+
+			t.Errorf("failed to create directory of golden file '%s': %v", path, err)
+
+			return false
+		}
+
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+
+			t.Errorf("failed to update golden file '%s': %v", path, err)
+
+			return false
+		}
+
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+
+		if errors.Is(err, fs.ErrNotExist) {
+
+			t.Errorf("golden file '%s' does not exist; %s to create it", path, update_instruction())
+		} else {
+
+			t.Errorf("failed to read golden file '%s': %v", path, err)
+		}
+
+		return false
+	}
+
+	if string(expected) != actual {
+
+		t.Errorf("actual value differs from golden file '%s' (- expected, + actual); %s to update it:\n%s", path, update_instruction(), line_diff(string(expected), actual))
+
+		return false
+	}
+
+	return true
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
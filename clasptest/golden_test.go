@@ -0,0 +1,163 @@
+package clasptest_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	"github.com/synesissoftware/CLASP.Go/clasptest"
+
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * globals
+ */
+
+// Defined by the test program, as described for clasptest.Update, such
+// that `go test ./clasptest -update` updates testdata/*.golden.
+var update = flag.Bool(clasptest.UpdateFlagName, false, "update golden files, rather than compare with them")
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+// Sets clasptest.Update for the duration of the test.
+func set_updating(t *testing.T, updating bool) {
+
+	previous := clasptest.Update
+
+	clasptest.Update = &updating
+
+	t.Cleanup(func() {
+
+		clasptest.Update = previous
+	})
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_AssertGolden_usage(t *testing.T) {
+
+	result, err := clasptest.RunShowUsage(usage_specifications(), clasp.UsageParams{
+
+		ProgramName: "myprog",
+		Version:     "1.2.3",
+		InfoLines:   []string{"CLASP.Go test program", ":version:", ""},
+	})
+
+	require.NoError(t, err)
+
+	clasptest.AssertGolden(t, filepath.Join("testdata", "usage.golden"), result.Output)
+}
+
+func Test_AssertGolden_mismatch(t *testing.T) {
+
+	set_updating(t, false)
+
+	path := filepath.Join(t.TempDir(), "mismatch.golden")
+
+	require.NoError(t, os.WriteFile(path, []byte("line 1\nline 2\nline 3\n"), 0o644))
+
+	rtb := new(recording_tb)
+
+	require.True(t, clasptest.AssertGolden(rtb, path, "line 1\nline 2\nline 3\n"))
+	require.Empty(t, rtb.errors)
+
+	require.False(t, clasptest.AssertGolden(rtb, path, "line 1\nline two\nline 3\n"))
+	require.Equal(t, 1, len(rtb.errors))
+	require.True(t, strings.HasSuffix(rtb.errors[0], "set clasptest.Update to update it:\n  line 1\n- line 2\n+ line two\n  line 3\n  \n"), rtb.errors[0])
+}
+
+func Test_AssertGolden_missing(t *testing.T) {
+
+	set_updating(t, false)
+
+	rtb := new(recording_tb)
+
+	path := filepath.Join(t.TempDir(), "missing.golden")
+
+	require.False(t, clasptest.AssertGolden(rtb, path, "abc"))
+	require.Equal(t, []string{"golden file '" + path + "' does not exist; set clasptest.Update to create it"}, rtb.errors)
+}
+
+func Test_AssertGolden_missing_names_flag_and_environment(t *testing.T) {
+
+	// the -update flag is defined by this test program
+
+	if *update {
+
+		t.Skip("updating golden files")
+	}
+
+	previous := clasptest.Update
+
+	clasptest.Update = nil
+
+	t.Cleanup(func() {
+
+		clasptest.Update = previous
+	})
+
+	t.Setenv(clasptest.UpdateEnvVarName, "")
+
+	rtb := new(recording_tb)
+
+	path := filepath.Join(t.TempDir(), "missing.golden")
+
+	require.False(t, clasptest.AssertGolden(rtb, path, "abc"))
+	require.Equal(t, []string{"golden file '" + path + "' does not exist; run the test with -update or CLASPTEST_UPDATE=1 to create it"}, rtb.errors)
+}
+
+func Test_AssertGolden_update_by_flag_and_environment(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "flagged.golden")
+
+	rtb := new(recording_tb)
+
+	previous := *update
+
+	t.Cleanup(func() {
+
+		*update = previous
+	})
+
+	*update = true
+
+	require.True(t, clasptest.AssertGolden(rtb, path, "by flag\n"))
+
+	*update = false
+
+	t.Setenv(clasptest.UpdateEnvVarName, "1")
+
+	require.True(t, clasptest.AssertGolden(rtb, path, "by environment\n"))
+	require.Empty(t, rtb.errors)
+
+	contents, err := os.ReadFile(path)
+
+	require.NoError(t, err)
+	require.Equal(t, "by environment\n", string(contents))
+}
+
+func Test_AssertGolden_update(t *testing.T) {
+
+	set_updating(t, true)
+
+	path := filepath.Join(t.TempDir(), "sub", "dir", "new.golden")
+
+	rtb := new(recording_tb)
+
+	require.True(t, clasptest.AssertGolden(rtb, path, "abc\n"))
+	require.Empty(t, rtb.errors)
+
+	contents, err := os.ReadFile(path)
+
+	require.NoError(t, err)
+	require.Equal(t, "abc\n", string(contents))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
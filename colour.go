@@ -0,0 +1,166 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"io"
+	"os"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Defines the ANSI escape sequences used to colourise usage and version
+// output (see [Usage_Colour] and [Usage_ColourAuto]). Each element that is
+// the empty string is not colourised.
+type ColourTheme struct {
+	Header      string // Sequence for headers, e.g. "USAGE:", "flags/options:", and section names.
+	Name        string // Sequence for flag/option names and aliases, and the program name.
+	Placeholder string // Sequence for placeholders, e.g. "<value>".
+	Value       string // Sequence for allowed values, and the version.
+	Reset       string // Sequence that restores the default rendition.
+}
+
+// Protocol for a stream that can report whether it is a terminal, which
+// may be implemented by (fake) streams for which [Usage_ColourAuto] is to
+// be controlled.
+type TerminalStream interface {
+	IsTerminal() bool
+}
+
+// Applies a colour theme to usage elements. If colour is not being used,
+// the theme is empty, and so no element is colourised.
+type usage_painter struct {
+	theme ColourTheme
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func is_terminal(stream io.Writer) bool {
+
+	switch s := stream.(type) {
+
+	case TerminalStream:
+
+		return s.IsTerminal()
+	case *os.File:
+
+		if fi, err := s.Stat(); err == nil {
+
+			return 0 != (fi.Mode() & os.ModeCharDevice)
+		}
+	}
+
+	return false
+}
+
+func is_env_set(params UsageParams, key string) bool {
+
+	value, ok := lookup_env(params, key)
+
+	return ok && "" != value
+}
+
+// Determines whether colour is to be used, being forced by [Usage_Colour]
+// or, if [Usage_ColourAuto] is specified, used when `NO_COLOR` is not set
+// and either `CLICOLOR_FORCE` is set (to a value other than "0") or the
+// stream is a terminal.
+func should_use_colour(params UsageParams) bool {
+
+	if 0 != (Usage_Colour & params.UsageFlags) {
+
+		return true
+	}
+
+	if 0 == (Usage_ColourAuto & params.UsageFlags) {
+
+		return false
+	}
+
+	if is_env_set(params, "NO_COLOR") {
+
+		return false
+	}
+
+	if value, _ := lookup_env(params, "CLICOLOR_FORCE"); "" != value && "0" != value {
+
+		return true
+	}
+
+	return is_terminal(params.Stream)
+}
+
+func new_usage_painter(params UsageParams) usage_painter {
+
+	if !should_use_colour(params) {
+
+		return usage_painter{}
+	}
+
+	if nil != params.Theme {
+
+		return usage_painter{theme: *params.Theme}
+	}
+
+	return usage_painter{theme: DefaultColourTheme()}
+}
+
+func (p usage_painter) paint(sequence, s string) string {
+
+	if "" == sequence || "" == s {
+
+		return s
+	}
+
+	return sequence + s + p.theme.Reset
+}
+
+func (p usage_painter) header(s string) string {
+
+	return p.paint(p.theme.Header, s)
+}
+
+func (p usage_painter) name(s string) string {
+
+	return p.paint(p.theme.Name, s)
+}
+
+func (p usage_painter) placeholder(s string) string {
+
+	return p.paint(p.theme.Placeholder, s)
+}
+
+func (p usage_painter) value(s string) string {
+
+	return p.paint(p.theme.Value, s)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains, by value, the default colour theme.
+func DefaultColourTheme() ColourTheme {
+
+	return ColourTheme{
+
+		Header:      "\x1b[1m",
+		Name:        "\x1b[1;36m",
+		Placeholder: "\x1b[4m",
+		Value:       "\x1b[32m",
+		Reset:       "\x1b[0m",
+	}
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,188 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+type fake_terminal struct {
+	bytes.Buffer
+	terminal bool
+}
+
+func (ft *fake_terminal) IsTerminal() bool {
+
+	return ft.terminal
+}
+
+func fake_env(vars map[string]string) func(key string) (string, bool) {
+
+	return func(key string) (string, bool) {
+
+		value, ok := vars[key]
+
+		return value, ok
+	}
+}
+
+func bracket_theme() *clasp.ColourTheme {
+
+	return &clasp.ColourTheme{
+
+		Header:      "<H>",
+		Name:        "<N>",
+		Placeholder: "<P>",
+		Value:       "<V>",
+		Reset:       "</>",
+	}
+}
+
+func colour_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+	}
+}
+
+func show_colour_usage_(t *testing.T, stream *fake_terminal, flags clasp.UsageFlag, env map[string]string) string {
+
+	t.Helper()
+
+	_, err := clasp.ShowUsage(colour_specifications(), clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines | flags,
+		Theme:       bracket_theme(),
+		LookupEnv:   fake_env(env),
+	})
+
+	require.Nil(t, err)
+
+	return stream.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowUsage_forced_Colour(t *testing.T) {
+
+	expected := "<H>USAGE:</> <N>myprog</> [ ... flags and options ... ]\n" +
+		"\n" +
+		"<H>flags/options:</>\n" +
+		"\n" +
+		"\t<H>behaviour:</>\n" +
+		"\n" +
+		"\t<N>-c</> <N>--verbosity=chatty</>\n" +
+		"\t<N>-V</> <P><value></>\n" +
+		"\t<N>--verbosity</>=<P><value></>\n" +
+		"\t\tSpecifies the verbosity\n" +
+		"\t\twhere <P><value></> one of:\n" +
+		"\t\t\t<V>terse</>\n" +
+		"\t\t\t<V>chatty</>\n"
+
+	stegol.CheckStringEqual(t, expected, show_colour_usage_(t, &fake_terminal{}, clasp.Usage_Colour, nil))
+}
+
+func Test_ShowUsage_forced_Colour_TwoColumnLayout(t *testing.T) {
+
+	expected := "<H>USAGE:</> <N>myprog</> [ ... flags and options ... ]\n" +
+		"\n" +
+		"<H>flags/options:</>\n" +
+		"<H>behaviour:</>\n" +
+		"  <N>-V</> <P><value></>, <N>--verbosity</>=<P><value></>\n" +
+		"                         Specifies the verbosity\n" +
+		"                         where <P><value></> one of:\n" +
+		"                           <V>terse</>, <V>chatty</> (<N>-c</>)\n"
+
+	params := clasp.UsageParams{
+
+		Stream:      &fake_terminal{},
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines | clasp.Usage_TwoColumnLayout | clasp.Usage_Colour,
+		Width:       50,
+		Theme:       bracket_theme(),
+	}
+
+	_, err := clasp.ShowUsage(colour_specifications(), params)
+
+	require.Nil(t, err)
+
+	stegol.CheckStringEqual(t, expected, params.Stream.(*fake_terminal).String())
+}
+
+func Test_ShowUsage_ColourAuto(t *testing.T) {
+
+	const coloured_first_line = "<H>USAGE:</> <N>myprog</> [ ... flags and options ... ]\n"
+	const plain_first_line = "USAGE: myprog [ ... flags and options ... ]\n"
+
+	tests := []struct {
+		terminal bool
+		env      map[string]string
+		expected string
+	}{
+		{false, nil, plain_first_line},
+		{true, nil, coloured_first_line},
+		{true, map[string]string{"NO_COLOR": "1"}, plain_first_line},
+		{true, map[string]string{"NO_COLOR": ""}, coloured_first_line},
+		{false, map[string]string{"CLICOLOR_FORCE": "1"}, coloured_first_line},
+		{false, map[string]string{"CLICOLOR_FORCE": "0"}, plain_first_line},
+		{false, map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, plain_first_line},
+	}
+
+	for i, test := range tests {
+
+		output := show_colour_usage_(t, &fake_terminal{terminal: test.terminal}, clasp.Usage_ColourAuto, test.env)
+
+		require.Equal(t, test.expected, output[:len(test.expected)], "test %d", i)
+	}
+}
+
+func Test_ShowUsage_no_Colour_by_default(t *testing.T) {
+
+	output := show_colour_usage_(t, &fake_terminal{terminal: true}, clasp.Usage_None, map[string]string{"CLICOLOR_FORCE": "1"})
+
+	require.NotContains(t, output, "</>")
+}
+
+func Test_ShowVersion_Colour(t *testing.T) {
+
+	stream := &fake_terminal{terminal: true}
+
+	_, err := clasp.ShowVersion(nil, clasp.UsageParams{
+
+		Stream:        stream,
+		ProgramName:   "myprog",
+		UsageFlags:    clasp.DontCallExit | clasp.Usage_ColourAuto,
+		Version:       "1.2.3",
+		VersionPrefix: "v",
+		Theme:         bracket_theme(),
+		LookupEnv:     fake_env(nil),
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "<N>myprog</> <V>v1.2.3</>\n", stream.String())
+}
+
+func Test_DefaultColourTheme(t *testing.T) {
+
+	theme := clasp.DefaultColourTheme()
+
+	require.Equal(t, "\x1b[0m", theme.Reset)
+	require.NotEmpty(t, theme.Header)
+	require.NotEmpty(t, theme.Name)
+	require.NotEmpty(t, theme.Placeholder)
+	require.NotEmpty(t, theme.Value)
+}
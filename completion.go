@@ -0,0 +1,494 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Enumeration type that tells the shell how to treat a completion for
+// which the program supplies no (or only partial) candidates.
+type CompletionHint int
+
+// Result of a completion request (see [Complete]).
+type Completion struct {
+	Candidates []string       // The candidates, each a complete replacement for the word being completed.
+	Hint       CompletionHint // The hint for the shell.
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	Completion_Default   CompletionHint = iota // The shell applies its default completion if there are no candidates.
+	Completion_None                            // The shell applies no further completion.
+	Completion_File                            // The shell additionally completes file names.
+	Completion_Directory                       // The shell additionally completes directory names.
+)
+
+// The hidden command-line argument, given as the first argument after the
+// program name, that requests completion. The remaining arguments are the
+// partial command line, the last of which is the word being completed.
+const CompletionCommand = "__complete"
+
+/* /////////////////////////////////////////////////////////////////////////
+ * builders
+ */
+
+// Builder method that specifies a function that obtains, at runtime, the
+// candidate values of an option whose value begins with the given prefix.
+//
+// The candidates obtained from the completer take precedence over
+// [Specification.ValueSet].
+func (specification Specification) SetCompleter(completer func(prefix string) []string) Specification {
+
+	specification.completer = completer
+
+	return specification
+}
+
+// Builder method that specifies the hint to be given to the shell when
+// completing the value of an option.
+func (specification Specification) SetCompletionHint(hint CompletionHint) Specification {
+
+	specification.completion_hint = hint
+
+	return specification
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func (hint CompletionHint) String() string {
+
+	switch hint {
+
+	case Completion_Default:
+
+		return "default"
+	case Completion_None:
+
+		return "none"
+	case Completion_File:
+
+		return "file"
+	case Completion_Directory:
+
+		return "directory"
+	default:
+
+		return fmt.Sprintf("<%T %d>", hint, hint)
+	}
+}
+
+// Rejoins words that bash has split on '=' (see `COMP_WORDBREAKS`), so
+// that `--name`, `=`, `value` is seen as `--name=value`.
+func merge_wordbreak_equals(words []string) []string {
+
+	merged := make([]string, 0, len(words))
+
+	for i := 0; i != len(words); i++ {
+
+		word := words[i]
+
+		if "=" == word && 0 != len(merged) && strings.HasPrefix(merged[len(merged)-1], "-") && !strings.Contains(merged[len(merged)-1], "=") {
+
+			merged[len(merged)-1] += "="
+
+			if i+1 != len(words) {
+
+				i++
+				merged[len(merged)-1] += words[i]
+			}
+
+			continue
+		}
+
+		merged = append(merged, word)
+	}
+
+	return merged
+}
+
+// Obtains a copy of the given parameters that gives no warnings (see
+// [ParseParams.WarningSink]), so that parsing during completion does not
+// warn of deprecated arguments.
+func quiet_parse_params(params ParseParams) ParseParams {
+
+	params.WarningSink = WarningSinkFunc(func(warning string) {})
+
+	return params
+}
+
+// Parses the given words, which precede the word being completed, as by
+// [Parse], and obtains the specification of the option, if any, that
+// awaits its value, and whether values have begun, either after the
+// double hyphen or, when parsing stops at the first value (see
+// [Parse_StopAtFirstValue]), at the first value.
+func completion_context(words []string, params ParseParams) (pending *Specification, treating_as_values bool) {
+
+	argv := append([]string{""}, words...)
+	last := len(argv) - 1
+
+	params = quiet_parse_params(params)
+
+	var bounds parse_bounds
+	var final Argument
+
+	parse_arguments_(argv, &params, &params, &bounds, func(arg *Argument) error {
+
+		final.Type = arg.Type
+		final.CmdLineIndex = arg.CmdLineIndex
+		final.CharOffset = arg.CharOffset
+		final.ArgumentSpecification = arg.ArgumentSpecification
+		final.alias_specification_ = arg.alias_specification_
+
+		return nil
+	})
+
+	// an option given by name - rather than as "--name=value", or via an
+	// option-value alias - as the last word, or followed only by the double
+	// hyphen, awaits its value
+
+	if OptionType == final.Type && 0 == final.CharOffset && nil == final.alias_specification_ && nil != final.ArgumentSpecification {
+
+		if index := final.CmdLineIndex; 0 != index && !strings.Contains(argv[index], "=") {
+
+			if last == index || (last == bounds.double_hyphen_index && last-1 == index) {
+
+				pending = final.ArgumentSpecification
+			}
+		}
+	}
+
+	treating_as_values = bounds.remainder_index >= 0
+
+	return
+}
+
+// Indicates whether the given word is a compound flag - e.g. "-xv" - as
+// recognised by [Parse].
+func is_compound_flag(word string, params ParseParams) bool {
+
+	params = quiet_parse_params(params)
+
+	compound := false
+
+	parse_arguments_([]string{"", word}, &params, &params, nil, func(arg *Argument) error {
+
+		compound = 0 != arg.CharOffset
+
+		return nil
+	})
+
+	return compound
+}
+
+func complete_value(specifications []Specification, specification *Specification, prefix, lead string) Completion {
+
+	var candidates []string
+
+	if nil != specification.completer {
+
+		candidates = specification.completer(prefix)
+	} else {
+
+		candidates = specification.ValueSet
+
+		// the value of each option-value alias - e.g. "chatty" of
+		// "--verbosity=chatty" - not already in the value set
+
+		for _, alias := range specifications {
+
+			if FlagType != alias.Type || !is_offered(alias) || !strings.HasPrefix(alias.Name, specification.Name+"=") {
+
+				continue
+			}
+
+			value := alias.Name[len(specification.Name)+1:]
+
+			if !slices.Contains(candidates, value) {
+
+				candidates = append(slices.Clip(candidates), value)
+			}
+		}
+	}
+
+	result := Completion{Hint: specification.completion_hint}
+
+	for _, candidate := range candidates {
+
+		if strings.HasPrefix(candidate, prefix) {
+
+			result.Candidates = append(result.Candidates, lead+candidate)
+		}
+	}
+
+	if nil == specification.completer && 0 != len(specification.ValueSet) && Completion_Default == result.Hint {
+
+		result.Hint = Completion_None
+	}
+
+	return result
+}
+
+func complete_name(specifications []Specification, prefix string) Completion {
+
+	result := Completion{Hint: Completion_None}
+
+	for _, specification := range specifications {
+
+		switch specification.Type {
+
+		case FlagType, OptionType:
+
+			if !is_offered(specification) {
+
+				continue
+			}
+
+			for _, name := range append([]string{specification.Name}, specification.Aliases...) {
+
+				if strings.HasPrefix(name, prefix) {
+
+					result.Candidates = append(result.Candidates, name)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Completes the given compound flag - e.g. "-xv" - with itself, followed
+// by it extended by the character of each short flag that it does not
+// already contain, e.g. "-xvq".
+func complete_compound_flag(specifications []Specification, word string) Completion {
+
+	result := Completion{Hint: Completion_None, Candidates: []string{word}}
+
+	for _, specification := range specifications {
+
+		if FlagType != specification.Type || !is_offered(specification) {
+
+			continue
+		}
+
+		for _, name := range append([]string{specification.Name}, specification.Aliases...) {
+
+			if 2 == len(name) && '-' == name[0] && '-' != name[1] && !strings.Contains(word[1:], name[1:]) {
+
+				result.Candidates = append(result.Candidates, word+name[1:])
+			}
+		}
+	}
+
+	return result
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Indicates whether the given argument string array - as would be passed
+// to [Parse] - is a completion request, i.e. whether its first argument
+// after the program name is [CompletionCommand].
+func IsCompletionRequest(argv []string) bool {
+
+	return len(argv) > 1 && CompletionCommand == argv[1]
+}
+
+// Determines the completion candidates for a completion request.
+//
+// The argument string array is in the form `<program> __complete <words>`,
+// where the last word is the (possibly empty) word being completed. The
+// preceding words are parsed, as by [Parse] (but without warnings of
+// deprecated arguments), to determine whether a flag/option name or the
+// value of an option is being completed. Option values are completed from
+// any completer (see [Specification.SetCompleter]) or, failing that, from
+// [Specification.ValueSet] and the values of any option-value aliases -
+// e.g. "chatty" of "--verbosity=chatty". A compound flag - e.g. "-xv" - is
+// completed with itself and with each short flag that may be appended to
+// it.
+func Complete(argv []string, params ParseParams) Completion {
+
+	var words []string
+
+	if IsCompletionRequest(argv) {
+
+		words = merge_wordbreak_equals(argv[2:])
+	}
+
+	current := ""
+
+	if 0 != len(words) {
+
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	pending, treatingAsValues := completion_context(words, params)
+
+	if nil != pending {
+
+		return complete_value(params.Specifications, pending, current, "")
+	}
+
+	if !treatingAsValues && strings.HasPrefix(current, "-") {
+
+		if ix_equals := strings.Index(current, "="); ix_equals >= 0 {
+
+			name := current[:ix_equals]
+
+			if found, specification, _ := params.findSpecification(name); found && OptionType == specification.Type {
+
+				return complete_value(params.Specifications, specification, current[ix_equals+1:], name+"=")
+			}
+
+			return Completion{Hint: Completion_None}
+		}
+
+		if completion := complete_name(params.Specifications, current); 0 != len(completion.Candidates) || !is_compound_flag(current, params) {
+
+			return completion
+		}
+
+		return complete_compound_flag(params.Specifications, current)
+	}
+
+	return Completion{Hint: Completion_Default}
+}
+
+// Handles a completion request, if the given argument string array is one
+// (see [IsCompletionRequest]), by writing the candidates - one per line -
+// followed by a directive line of the form `:<hint>` to the given stream
+// (or standard output if `nil`) and then exiting, with exit code 0, via the
+// given exiter (or [os.Exit] if `nil`).
+//
+// Returns `false` if the argument string array is not a completion
+// request, or if the exiter returns.
+func HandleCompletion(argv []string, params ParseParams, stream io.Writer, exiter Exiter) bool {
+
+	if !IsCompletionRequest(argv) {
+
+		return false
+	}
+
+	if nil == stream {
+
+		stream = os.Stdout
+	}
+
+	if nil == exiter {
+
+		exiter = new(default_exiter)
+	}
+
+	completion := Complete(argv, params)
+
+	for _, candidate := range completion.Candidates {
+
+		fmt.Fprintf(stream, "%s\n", candidate)
+	}
+	fmt.Fprintf(stream, ":%v\n", completion.Hint)
+
+	exiter.Exit(0)
+
+	return true
+}
+
+// Writes to the given stream a bash completion script for the named
+// program that obtains its candidates by invoking the program with
+// [CompletionCommand] (see [HandleCompletion]).
+//
+// The program name is shell-quoted as necessary (see [ShellQuote]), and an
+// error is returned if it is empty or contains a line break.
+func GenerateBashCompletionScript(programName string, w io.Writer) error {
+
+	if "" == programName || strings.ContainsAny(programName, "\r\n") {
+
+		return fmt.Errorf("GenerateBashCompletionScript() called with an invalid program name %q", programName)
+	}
+
+	function_name := "_clasp_complete_" + strings.Map(func(c rune) rune {
+
+		switch {
+
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+
+			return c
+		default:
+
+			return '_'
+		}
+	}, programName)
+
+	_, err := fmt.Fprintf(w, `# bash completion for %[1]s, generated by CLASP.Go
+
+%[2]s()
+{
+	local IFS=$'\n'
+	local lines hint word prefix
+
+	lines=( $("${COMP_WORDS[0]}" %[3]s "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null) )
+
+	hint="${lines[${#lines[@]}-1]}"
+	unset 'lines[${#lines[@]}-1]'
+
+	word="${COMP_LINE:0:COMP_POINT}"
+	word="${word##*[[:space:]]}"
+	if [[ "$word" == *=* && "$COMP_WORDBREAKS" == *=* ]]; then
+
+		prefix="${word%%=*}="
+		lines=( "${lines[@]#"$prefix"}" )
+	fi
+
+	COMPREPLY=( "${lines[@]}" )
+
+	case "$hint" in
+	:file)
+
+		COMPREPLY+=( $(compgen -f -- "${COMP_WORDS[COMP_CWORD]#=}") )
+		;;
+	:directory)
+
+		COMPREPLY+=( $(compgen -d -- "${COMP_WORDS[COMP_CWORD]#=}") )
+		;;
+	:none)
+
+		;;
+	*)
+
+		if [[ ${#COMPREPLY[@]} -eq 0 ]]; then
+
+			compopt -o default
+		fi
+		;;
+	esac
+}
+
+complete -F %[2]s %[1]s
+`, shell_quote(programName), function_name, CompletionCommand)
+
+	return err
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,323 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	"github.com/synesissoftware/CLASP.Go/clasptest"
+
+	"bytes"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func completion_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--verbosity").SetHelp("Specifies the verbosity").SetValues("terse", "quiet", "silent", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+		clasp.Option("--branch").SetAlias("-b").SetCompleter(func(prefix string) []string {
+
+			return []string{"main", "master", "develop"}
+		}).SetCompletionHint(clasp.Completion_None),
+		clasp.Option("--input").SetCompletionHint(clasp.Completion_File),
+		clasp.Option("--output-dir").SetCompletionHint(clasp.Completion_Directory),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+		clasp.VersionFlag(),
+	}
+}
+
+func complete_(words ...string) clasp.Completion {
+
+	argv := append([]string{"myprog", clasp.CompletionCommand}, words...)
+
+	return clasp.Complete(argv, clasp.ParseParams{Specifications: completion_specifications()})
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_IsCompletionRequest(t *testing.T) {
+
+	require.False(t, clasp.IsCompletionRequest(nil))
+	require.False(t, clasp.IsCompletionRequest([]string{"myprog"}))
+	require.False(t, clasp.IsCompletionRequest([]string{"myprog", "--help"}))
+	require.True(t, clasp.IsCompletionRequest([]string{"myprog", "__complete"}))
+	require.True(t, clasp.IsCompletionRequest([]string{"myprog", "__complete", "--v"}))
+}
+
+func Test_Complete_flag_and_option_names(t *testing.T) {
+
+	completion := complete_("--verb")
+
+	require.Equal(t, []string{"--verbose", "--verbosity", "--verbosity=chatty"}, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+
+	completion = complete_("-")
+
+	require.Contains(t, completion.Candidates, "-v")
+	require.Contains(t, completion.Candidates, "-c")
+	require.Contains(t, completion.Candidates, "--help")
+	require.NotContains(t, completion.Candidates, "behaviour:")
+}
+
+func Test_Complete_option_value_from_ValueSet(t *testing.T) {
+
+	completion := complete_("--verbosity=")
+
+	require.Equal(t, []string{"--verbosity=terse", "--verbosity=quiet", "--verbosity=silent", "--verbosity=chatty"}, completion.Candidates)
+
+	completion = complete_("--verbosity=s")
+
+	require.Equal(t, []string{"--verbosity=silent"}, completion.Candidates)
+
+	completion = complete_("--verbosity", "t")
+
+	require.Equal(t, []string{"terse"}, completion.Candidates)
+
+	completion = complete_("--verbosity", "x")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+}
+
+func Test_Complete_option_value_with_bash_wordbreaks(t *testing.T) {
+
+	completion := complete_("--verbosity", "=", "q")
+
+	require.Equal(t, []string{"--verbosity=quiet"}, completion.Candidates)
+
+	completion = complete_("--verbosity", "=")
+
+	require.Len(t, completion.Candidates, 4)
+}
+
+func Test_Complete_option_value_from_completer(t *testing.T) {
+
+	completion := complete_("-b", "ma")
+
+	require.Equal(t, []string{"main", "master"}, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+
+	completion = complete_("-v", "--branch=d")
+
+	require.Equal(t, []string{"--branch=develop"}, completion.Candidates)
+}
+
+func Test_Complete_option_value_hints(t *testing.T) {
+
+	completion := complete_("--input", "")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_File, completion.Hint)
+
+	completion = complete_("--output-dir", "/t")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Directory, completion.Hint)
+}
+
+func Test_Complete_option_value_from_option_value_aliases(t *testing.T) {
+
+	params := clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Option("--level"),
+			clasp.Flag("--level=high").SetAlias("-H"),
+			clasp.Flag("--level=low").SetAlias("-L"),
+			clasp.Flag("--level=legacy").SetDeprecated("", "--level=low"),
+		},
+	}
+
+	completion := clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--level="}, params)
+
+	require.Equal(t, []string{"--level=high", "--level=low"}, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--level", "l"}, params)
+
+	require.Equal(t, []string{"low"}, completion.Candidates)
+
+	// an option-value alias is complete, and so awaits no value
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-H", ""}, params)
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+}
+
+func Test_Complete_compound_flags(t *testing.T) {
+
+	params := clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--extract").SetAlias("-x"),
+			clasp.Flag("--verbose").SetAlias("-v"),
+			clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+			clasp.Option("--verbosity").SetValues("terse", "chatty"),
+			clasp.Option("--file").SetAlias("-f").SetCompletionHint(clasp.Completion_File),
+		},
+	}
+
+	completion := clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-xv"}, params)
+
+	require.Equal(t, []string{"-xv", "-xvc"}, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-xvc"}, params)
+
+	require.Equal(t, []string{"-xvc"}, completion.Candidates)
+
+	// not a compound flag, since -f is an option
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-xf"}, params)
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+
+	// a preceding compound flag awaits no value
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-xv", "-f", ""}, params)
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_File, completion.Hint)
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "-xc", "--verb"}, params)
+
+	require.Equal(t, []string{"--verbose", "--verbosity=chatty", "--verbosity"}, completion.Candidates)
+}
+
+func Test_Complete_does_not_warn_of_deprecated_arguments(t *testing.T) {
+
+	var warnings []string
+
+	params := clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--old").SetDeprecated("", "--new"),
+			clasp.Flag("--new"),
+		},
+		WarningSink: clasp.WarningSinkFunc(func(warning string) {
+
+			warnings = append(warnings, warning)
+		}),
+	}
+
+	completion := clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--old", "--n"}, params)
+
+	require.Equal(t, []string{"--new"}, completion.Candidates)
+	require.Empty(t, warnings)
+}
+
+func Test_Complete_values(t *testing.T) {
+
+	completion := complete_("--verbose", "abc", "")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+
+	completion = complete_("--", "--verb")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+
+	// as with Parse(), an option's value may follow a double hyphen
+
+	completion = complete_("--branch", "--", "--verb")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_None, completion.Hint)
+
+	completion = complete_("--branch", "--", "main", "--verb")
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+}
+
+func Test_Complete_StopAtFirstValue(t *testing.T) {
+
+	params := clasp.ParseParams{
+
+		Specifications: completion_specifications(),
+		Flags:          clasp.Parse_StopAtFirstValue,
+	}
+
+	completion := clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--verbose", "cmd", "--verb"}, params)
+
+	require.Empty(t, completion.Candidates)
+	require.Equal(t, clasp.Completion_Default, completion.Hint)
+
+	completion = clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--verbose", "--verb"}, params)
+
+	require.Contains(t, completion.Candidates, "--verbose")
+}
+
+func Test_HandleCompletion(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+	exiter := new(clasptest.RecordingExiter)
+	params := clasp.ParseParams{Specifications: completion_specifications()}
+
+	require.False(t, clasp.HandleCompletion([]string{"myprog", "--help"}, params, buf, exiter))
+	require.False(t, exiter.Exited)
+	require.Empty(t, buf.String())
+
+	require.True(t, clasp.HandleCompletion([]string{"myprog", "__complete", "--verbosity", "s"}, params, buf, exiter))
+	require.True(t, exiter.Exited)
+	require.Equal(t, 0, exiter.ExitCode)
+	require.Equal(t, "silent\n:none\n", buf.String())
+}
+
+func Test_GenerateBashCompletionScript(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateBashCompletionScript("my-prog", buf)
+
+	require.Nil(t, err)
+
+	script := buf.String()
+
+	require.True(t, strings.Contains(script, "_clasp_complete_my_prog()"))
+	require.True(t, strings.Contains(script, `"${COMP_WORDS[0]}" __complete`))
+	require.True(t, strings.HasSuffix(script, "complete -F _clasp_complete_my_prog my-prog\n"))
+}
+
+func Test_GenerateBashCompletionScript_quotes_program_name(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateBashCompletionScript("my prog;rm -rf ~", buf)
+
+	require.Nil(t, err)
+
+	script := buf.String()
+
+	require.True(t, strings.HasPrefix(script, "# bash completion for 'my prog;rm -rf ~', generated by CLASP.Go\n"))
+	require.True(t, strings.HasSuffix(script, "complete -F _clasp_complete_my_prog_rm__rf__ 'my prog;rm -rf ~'\n"))
+}
+
+func Test_GenerateBashCompletionScript_rejects_invalid_program_name(t *testing.T) {
+
+	for _, programName := range []string{"", "my-prog\necho pwned"} {
+
+		buf := new(bytes.Buffer)
+
+		err := clasp.GenerateBashCompletionScript(programName, buf)
+
+		require.NotNil(t, err)
+		require.Equal(t, 0, buf.Len())
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"unicode/utf8"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Protocol for receiving warnings, such as of the use of a deprecated
+// argument (see [Specification.SetDeprecated]).
+type WarningSink interface {
+	Warn(warning string)
+}
+
+// Function type that implements [WarningSink].
+type WarningSinkFunc func(warning string)
+
+func (fn WarningSinkFunc) Warn(warning string) {
+
+	fn(warning)
+}
+
+type deprecation_info struct {
+	message     string
+	replacement string
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+type default_warning_sink struct{}
+
+func (dws default_warning_sink) Warn(warning string) {
+
+	fmt.Fprintln(os.Stderr, warning)
+}
+
+// Obtains the name by which the given argument was given: for a flag
+// obtained from a compound flag, its character, e.g. "-q" from "-xqz";
+// for an option given via an alias, the alias, e.g. "-c" rather than
+// "--verbosity=chatty".
+func given_name(argv []string, arg *Argument) string {
+
+	if arg.CmdLineIndex < len(argv) {
+
+		if 0 != arg.CharOffset && arg.CharOffset < len(argv[arg.CmdLineIndex]) {
+
+			c, _ := utf8.DecodeRuneInString(argv[arg.CmdLineIndex][arg.CharOffset:])
+
+			return "-" + string(c)
+		}
+
+		if nil != arg.alias_specification_ {
+
+			return argv[arg.CmdLineIndex]
+		}
+	}
+
+	return arg.GivenName
+}
+
+// Obtains the deprecated specification by which the given argument was
+// matched, if any: the alias via which it was given (see
+// [Argument.alias_specification_]), if that is deprecated, or otherwise
+// its specification.
+func deprecated_specification(arg *Argument) *Specification {
+
+	for _, spec := range []*Specification{arg.alias_specification_, arg.ArgumentSpecification} {
+
+		if nil != spec && nil != spec.deprecation {
+
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// Appends to the given text the deprecation message and replacement, if
+// any, e.g. "...: no longer necessary; use --new instead".
+func append_deprecation(text string, deprecation *deprecation_info, catalogue MessageCatalogue, locale string) string {
+
+	if "" != deprecation.message {
+
+		text += ": " + deprecation.message
+	}
+
+	if "" != deprecation.replacement {
+
+		text += "; " + substitute_arguments(lookup_message(catalogue, locale, Message_UseInstead), deprecation.replacement)
+	}
+
+	return text
+}
+
+// Obtains a function that marks as deprecated each argument whose
+// specification is deprecated, and warns, once for each specification,
+// of its use, before passing it to emit.
+func deprecation_emitter(argv []string, params *ParseParams, emit func(arg *Argument) error) func(arg *Argument) error {
+
+	var warned map[string]bool
+
+	return func(arg *Argument) error {
+
+		if spec := deprecated_specification(arg); nil != spec {
+
+			arg.Deprecated = true
+
+			if !warned[spec.Name] {
+
+				if nil == warned {
+
+					warned = make(map[string]bool)
+				}
+
+				warned[spec.Name] = true
+
+				sink := params.WarningSink
+				if nil == sink {
+
+					sink = default_warning_sink{}
+				}
+
+				program_name := ""
+				if 0 != len(argv) {
+
+					program_name = path.Base(argv[0])
+				}
+
+				warning := substitute_arguments(lookup_message(params.Messages, params.Locale, Message_DeprecatedWarning), program_name, given_name(argv, arg))
+
+				sink.Warn(append_deprecation(warning, spec.deprecation, params.Messages, params.Locale))
+			}
+		}
+
+		return emit(arg)
+	}
+}
+
+// Obtains a copy of the given specifications as they are to be presented in
+// usage and generated documentation, with each help localised (see
+// [localise_specifications]), and without hidden specifications, nor,
+// unless [Usage_ShowAll] is specified, deprecated specifications, nor,
+// unless a help topic is specified (see [UsageParams.HelpTopic]), advanced
+// specifications (see [Specification.SetAdvanced]), and any section that
+// thereby has no specifications. Where shown, the help of a deprecated
+// specification is annotated as such.
+func presented_specifications(specifications []Specification, params UsageParams) []Specification {
+
+	specifications = localise_specifications(specifications, params)
+
+	show_all := 0 != (Usage_ShowAll & params.UsageFlags)
+	show_advanced := show_all || "" != params.HelpTopic
+
+	presented := make([]Specification, 0, len(specifications))
+
+	section := -1
+	num_shown := 0
+	num_omitted := 0
+
+	drop_empty_section := func() {
+
+		if section >= 0 && 0 == num_shown && 0 != num_omitted {
+
+			presented = presented[:section]
+		}
+	}
+
+	for _, specification := range specifications {
+
+		switch {
+
+		case SectionType == specification.Type:
+
+			drop_empty_section()
+
+			section = len(presented)
+			num_shown = 0
+			num_omitted = 0
+
+			presented = append(presented, specification)
+		case specification.hidden:
+
+			num_omitted++
+		case nil != specification.deprecation && !show_all:
+
+			num_omitted++
+		case specification.advanced && !show_advanced:
+
+			num_omitted++
+		default:
+
+			if nil != specification.deprecation {
+
+				note := append_deprecation(lookup_message(params.Messages, params.Locale, Message_DeprecatedNote), specification.deprecation, params.Messages, params.Locale)
+
+				if "" == specification.Help {
+
+					specification.Help = "(" + note + ")"
+				} else {
+
+					specification.Help += " (" + note + ")"
+				}
+			}
+
+			presented = append(presented, specification)
+
+			num_shown++
+		}
+	}
+
+	drop_empty_section()
+
+	return presented
+}
+
+// Indicates whether the given specification is offered in completions and
+// suggestions, which hidden and deprecated specifications are not.
+func is_offered(specification Specification) bool {
+
+	return !specification.hidden && nil == specification.deprecation
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Builder method that causes the specification to be hidden: arguments
+// are parsed as normal, but it is always omitted from usage (see
+// [ShowUsage]) - including when [Usage_ShowAll] is specified - from
+// generated documentation, and from completions and suggestions.
+func (specification Specification) SetHidden() Specification {
+
+	specification.hidden = true
+
+	return specification
+}
+
+// Builder method that causes the specification to be deprecated:
+// arguments are parsed as normal, but each matching [Argument] is marked
+// [Argument.Deprecated], and a warning - e.g. "myprog: warning: --old is
+// deprecated: <message>; use <replacement> instead" - is given, once per
+// parse, to the warning sink (see [ParseParams.WarningSink]). It is
+// omitted from usage and generated documentation unless [Usage_ShowAll]
+// is specified, whereupon its help is annotated as deprecated, and from
+// completions and suggestions.
+//
+// A deprecated option-value alias - e.g. "-c" for "--verbosity=chatty" -
+// causes arguments given via the alias, but not via the option itself, to
+// be marked and warned of.
+//
+// Either, or both, of message and replacement may be empty.
+func (specification Specification) SetDeprecated(message, replacement string) Specification {
+
+	specification.deprecation = &deprecation_info{message, replacement}
+
+	return specification
+}
+
+// Indicates whether the specification is hidden (see
+// [Specification.SetHidden]).
+func (specification Specification) IsHidden() bool {
+
+	return specification.hidden
+}
+
+// Indicates whether the specification is deprecated (see
+// [Specification.SetDeprecated]).
+func (specification Specification) IsDeprecated() bool {
+
+	return nil != specification.deprecation
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
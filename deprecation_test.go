@@ -0,0 +1,268 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func deprecation_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--quiet").SetAlias("-q").SetHelp("Suppresses output"),
+		clasp.Flag("--silent").SetHelp("Suppresses all output").SetDeprecated("", "--quiet"),
+		clasp.Flag("--debug-internals").SetAlias("-D").SetHelp("Dumps internal state").SetHidden(),
+
+		clasp.Section("legacy:"),
+		clasp.Option("--old-level").SetHelp("Specifies the level").SetDeprecated("levels are now automatic", ""),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+	}
+}
+
+func recording_warning_sink(warnings *[]string) clasp.WarningSink {
+
+	return clasp.WarningSinkFunc(func(warning string) {
+
+		*warnings = append(*warnings, warning)
+	})
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_SetHidden_parses_normally(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "-D", "--debug-internals"}, clasp.ParseParams{Specifications: deprecation_specifications()})
+
+	require.Equal(t, 2, len(args.Flags))
+
+	for _, f := range args.Flags {
+
+		require.Equal(t, "--debug-internals", f.ResolvedName)
+		require.False(t, f.Deprecated)
+	}
+
+	require.True(t, clasp.Flag("--x").SetHidden().IsHidden())
+	require.False(t, clasp.Flag("--x").IsHidden())
+}
+
+func Test_SetDeprecated_warns_once(t *testing.T) {
+
+	var warnings []string
+
+	args := clasp.Parse([]string{"/usr/bin/myprog", "--silent", "-q", "--old-level", "3", "--silent"}, clasp.ParseParams{
+
+		Specifications: deprecation_specifications(),
+		WarningSink:    recording_warning_sink(&warnings),
+	})
+
+	require.Equal(t, []string{
+
+		"myprog: warning: --silent is deprecated; use --quiet instead",
+		"myprog: warning: --old-level is deprecated: levels are now automatic",
+	}, warnings)
+
+	require.True(t, args.Flags[0].Deprecated)
+	require.False(t, args.Flags[1].Deprecated)
+	require.True(t, args.Flags[2].Deprecated)
+	require.True(t, args.Options[0].Deprecated)
+	require.Equal(t, "3", args.Options[0].Value)
+
+	require.True(t, args.Flags[0].ArgumentSpecification.IsDeprecated())
+}
+
+func Test_SetDeprecated_compound_flag_and_locale(t *testing.T) {
+
+	var warnings []string
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v"),
+		clasp.Flag("--old").SetAlias("-o").SetDeprecated("", "--new"),
+	}
+
+	args := clasp.Parse([]string{"myprog", "-vo"}, clasp.ParseParams{
+
+		Specifications: specifications,
+		WarningSink:    recording_warning_sink(&warnings),
+		Locale:         "de_DE",
+	})
+
+	require.Equal(t, 2, len(args.Flags))
+	require.Equal(t, []string{"myprog: Warnung: -o ist veraltet; verwenden Sie stattdessen --new"}, warnings)
+}
+
+func Test_SetDeprecated_option_value_alias(t *testing.T) {
+
+	var warnings []string
+
+	specifications := []clasp.Specification{
+
+		clasp.Option("--verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c").SetDeprecated("", "--verbosity=chatty"),
+		clasp.Flag("--verbose").SetAlias("-v"),
+		clasp.Flag("--verbosity=terse").SetAlias("-t"),
+	}
+
+	args := clasp.Parse([]string{"myprog", "-c", "--verbosity=chatty", "-vc", "-t"}, clasp.ParseParams{
+
+		Specifications: specifications,
+		WarningSink:    recording_warning_sink(&warnings),
+	})
+
+	require.Equal(t, []string{"myprog: warning: -c is deprecated; use --verbosity=chatty instead"}, warnings)
+
+	require.Equal(t, 4, len(args.Options))
+
+	require.Equal(t, "--verbosity", args.Options[0].ResolvedName)
+	require.Equal(t, "chatty", args.Options[0].Value)
+	require.True(t, args.Options[0].Deprecated)
+
+	require.Equal(t, "--verbosity", args.Options[1].ResolvedName)
+	require.False(t, args.Options[1].Deprecated)
+
+	require.Equal(t, "chatty", args.Options[2].Value)
+	require.True(t, args.Options[2].Deprecated)
+
+	require.Equal(t, "terse", args.Options[3].Value)
+	require.False(t, args.Options[3].Deprecated)
+}
+
+func Test_ParseFunc_SetDeprecated(t *testing.T) {
+
+	var warnings []string
+	var deprecated []bool
+
+	err := clasp.ParseFunc([]string{"myprog", "--silent", "--quiet"}, clasp.ParseParams{
+
+		Specifications: deprecation_specifications(),
+		WarningSink:    recording_warning_sink(&warnings),
+	}, func(arg *clasp.Argument) error {
+
+		deprecated = append(deprecated, arg.Deprecated)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false}, deprecated)
+	require.Equal(t, 1, len(warnings))
+}
+
+func Test_ShowUsage_omits_hidden_and_deprecated(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	_, err := clasp.ShowUsage(deprecation_specifications(), clasp.UsageParams{
+
+		Stream:      &buf,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines,
+	})
+
+	require.NoError(t, err)
+
+	// the "legacy:" section has no specifications that are shown, so is
+	// omitted
+
+	stegol.CheckStringEqual(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+	behaviour:
+
+	-q
+	--quiet
+		Suppresses output
+
+	standard:
+
+	--help
+		Shows this help and exits
+`, buf.String())
+}
+
+func Test_ShowUsage_ShowAll_shows_deprecated_but_not_hidden(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	_, err := clasp.ShowUsage(deprecation_specifications(), clasp.UsageParams{
+
+		Stream:      &buf,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines | clasp.Usage_ShowAll,
+	})
+
+	require.NoError(t, err)
+
+	stegol.CheckStringEqual(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+	behaviour:
+
+	-q
+	--quiet
+		Suppresses output
+	--silent
+		Suppresses all output (deprecated; use --quiet instead)
+
+	legacy:
+
+	--old-level=<value>
+		Specifies the level (deprecated: levels are now automatic)
+
+	standard:
+
+	--help
+		Shows this help and exits
+`, buf.String())
+}
+
+func Test_Complete_omits_hidden_and_deprecated(t *testing.T) {
+
+	completion := clasp.Complete([]string{"myprog", clasp.CompletionCommand, "--"}, clasp.ParseParams{Specifications: deprecation_specifications()})
+
+	require.Equal(t, []string{"--quiet", "--help"}, completion.Candidates)
+}
+
+func Test_Suggest_omits_hidden_and_deprecated(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "--silemt", "--debug-internal"}, clasp.ParseParams{Specifications: deprecation_specifications()})
+
+	require.Empty(t, args.Suggest(args.Flags[0]))
+	require.Empty(t, args.Suggest(args.Flags[1]))
+}
+
+func Test_SaveSpecifications_hidden_and_deprecated(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	require.NoError(t, clasp.SaveSpecifications(deprecation_specifications(), &buf))
+
+	require.Contains(t, buf.String(), `"hidden": true`)
+	require.Contains(t, buf.String(), `"replacement": "--quiet"`)
+
+	loaded, err := clasp.LoadSpecifications(&buf)
+
+	require.NoError(t, err)
+	require.True(t, loaded[2].IsDeprecated())
+	require.True(t, loaded[3].IsHidden())
+	require.True(t, loaded[5].IsDeprecated())
+	require.False(t, loaded[1].IsDeprecated())
+	require.False(t, loaded[1].IsHidden())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,212 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	diagnostic_indent = "    "
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Obtains the span - offset and length, in bytes - within its command-line
+// argument of the given argument: the character from which it was obtained
+// if from a compound flag, or otherwise the whole of the argument.
+func argument_span(argv []string, arg *Argument) (offset, length int) {
+
+	if 0 != arg.CharOffset && arg.CmdLineIndex < len(argv) && arg.CharOffset < len(argv[arg.CmdLineIndex]) {
+
+		_, n := utf8.DecodeRuneInString(argv[arg.CmdLineIndex][arg.CharOffset:])
+
+		return arg.CharOffset, n
+	}
+
+	return 0, 0
+}
+
+// Obtains the span of an unrecognised argument, which, for an apparent
+// compound flag, is the first character that does not correspond to a
+// flag, since it is that which prevented it being recognised.
+func (args *Arguments) unrecognised_span(arg *Argument) (offset, length int) {
+
+	if 0 != arg.CharOffset {
+
+		return argument_span(args.Argv, arg)
+	}
+
+	if FlagType == arg.Type && 1 == arg.NumGivenHyphens && len(arg.GivenName) > 2 && nil == arg.ArgumentSpecification {
+
+		for j, c := range arg.GivenName {
+
+			if 0 == j {
+
+				continue
+			}
+
+			if !args.has_short_flag(c) {
+
+				return j, utf8.RuneLen(c)
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+func (args *Arguments) has_short_flag(c rune) bool {
+
+	name := "-" + string(c)
+
+	for _, spec := range args.specifications {
+
+		if FlagType != spec.Type {
+
+			continue
+		}
+
+		if name == spec.Name {
+
+			return true
+		}
+
+		for _, alias := range spec.Aliases {
+
+			if name == alias {
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Renders a diagnostic comprising the given message followed by the
+// command line - as by [ShellQuote], but with the program name in place of
+// `argv[0]` - and a line with a caret indicating the length bytes at
+// charOffset within the command-line argument at cmdLineIndex, or the
+// whole of it if length is 0. For example:
+//
+//	myprog: unrecognised flag/option: -xqz
+//	    myprog -xqz --verbose
+//	             ^
+//
+// If cmdLineIndex does not identify a command-line argument, the message
+// alone is obtained.
+func FormatDiagnosticAt(message string, argv []string, cmdLineIndex, charOffset, length int) string {
+
+	if cmdLineIndex < 1 || cmdLineIndex >= len(argv) {
+
+		return message
+	}
+
+	s := argv[cmdLineIndex]
+
+	if charOffset < 0 || charOffset > len(s) {
+
+		charOffset = 0
+	}
+
+	if length <= 0 || charOffset+length > len(s) {
+
+		charOffset, length = 0, len(s)
+	}
+
+	var sb strings.Builder
+
+	column := 0
+
+	sb.WriteString(message)
+	sb.WriteString("\n")
+	sb.WriteString(diagnostic_indent)
+
+	for i, arg := range argv {
+
+		if 0 == i {
+
+			arg = path.Base(arg)
+		} else {
+
+			sb.WriteString(" ")
+		}
+
+		quoted := shell_quote(arg)
+
+		if i < cmdLineIndex {
+
+			column += display_width(quoted) + 1
+		}
+
+		sb.WriteString(quoted)
+	}
+
+	width := display_width(shell_escape(s[charOffset : charOffset+length]))
+
+	if needs_shell_quote(s) {
+
+		column += 1 + display_width(shell_escape(s[:charOffset]))
+
+		if 0 == length {
+
+			// the quotes of an empty argument are indicated
+
+			column--
+			width = 2
+		}
+	} else {
+
+		column += display_width(s[:charOffset])
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(diagnostic_indent)
+	sb.WriteString(strings.Repeat(" ", column))
+	sb.WriteString("^")
+
+	if width > 1 {
+
+		sb.WriteString(strings.Repeat("~", width-1))
+	}
+
+	return sb.String()
+}
+
+// Renders a diagnostic comprising the given message followed by the
+// command line and a line with a caret indicating the given argument, as
+// described for [FormatDiagnosticAt]. If the argument was obtained from a
+// compound flag, only its character is indicated (see
+// [Argument.CharOffset]).
+//
+// This may be used for reporting problems found by the program after
+// parsing, e.g. an option value that is out of range.
+func (args *Arguments) FormatDiagnostic(arg *Argument, message string) string {
+
+	offset, length := argument_span(args.Argv, arg)
+
+	return FormatDiagnosticAt(message, args.Argv, arg.CmdLineIndex, offset, length)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
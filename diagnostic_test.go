@@ -0,0 +1,190 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func diagnostic_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Flag("--extract").SetAlias("-x"),
+		clasp.Flag("--quiet").SetAlias("-q"),
+		clasp.Flag("--gzip").SetAlias("-z"),
+		clasp.Option("--level"),
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_Argument_CharOffset(t *testing.T) {
+
+	args := clasp.Parse([]string{"/usr/bin/myprog", "-xqz", "--level=3", "-q"}, clasp.ParseParams{Specifications: diagnostic_specifications()})
+
+	require.Equal(t, 4, len(args.Flags))
+
+	require.Equal(t, 1, args.Flags[0].CharOffset)
+	require.Equal(t, 2, args.Flags[1].CharOffset)
+	require.Equal(t, 3, args.Flags[2].CharOffset)
+	require.Equal(t, 0, args.Flags[3].CharOffset)
+	require.Equal(t, 0, args.Options[0].CharOffset)
+
+	for _, f := range args.Flags[:3] {
+
+		require.Equal(t, 1, f.CmdLineIndex)
+	}
+}
+
+func Test_FormatDiagnostic(t *testing.T) {
+
+	args := clasp.Parse([]string{"/usr/bin/myprog", "-xqz", "--level=3", "-q"}, clasp.ParseParams{Specifications: diagnostic_specifications()})
+
+	stegol.CheckStringEqual(t, ""+
+		"myprog: -q conflicts with -x\n"+
+		"    myprog -xqz --level=3 -q\n"+
+		"             ^",
+		args.FormatDiagnostic(args.Flags[1], "myprog: -q conflicts with -x"))
+
+	stegol.CheckStringEqual(t, ""+
+		"myprog: level out of range\n"+
+		"    myprog -xqz --level=3 -q\n"+
+		"                ^~~~~~~~~",
+		args.FormatDiagnostic(args.Options[0], "myprog: level out of range"))
+
+	stegol.CheckStringEqual(t, ""+
+		"myprog: bad\n"+
+		"    myprog -xqz --level=3 -q\n"+
+		"                          ^~",
+		args.FormatDiagnostic(args.Flags[3], "myprog: bad"))
+}
+
+func Test_FormatDiagnosticAt(t *testing.T) {
+
+	argv := []string{"myprog", "--name=it's", "", "日本語", "x"}
+
+	// within a quoted argument
+
+	stegol.CheckStringEqual(t, ""+
+		"m\n"+
+		"    myprog '--name=it'\\''s' '' '日本語' x\n"+
+		"                   ^~~~~~~",
+		clasp.FormatDiagnosticAt("m", argv, 1, 7, 4))
+
+	// an empty argument
+
+	stegol.CheckStringEqual(t, ""+
+		"m\n"+
+		"    myprog '--name=it'\\''s' '' '日本語' x\n"+
+		"                            ^~",
+		clasp.FormatDiagnosticAt("m", argv, 2, 0, 0))
+
+	// wide characters, each occupying two columns
+
+	stegol.CheckStringEqual(t, ""+
+		"m\n"+
+		"    myprog '--name=it'\\''s' '' '日本語' x\n"+
+		"                                  ^~",
+		clasp.FormatDiagnosticAt("m", argv, 3, 3, 3))
+
+	stegol.CheckStringEqual(t, ""+
+		"m\n"+
+		"    myprog '--name=it'\\''s' '' '日本語' x\n"+
+		"                                        ^",
+		clasp.FormatDiagnosticAt("m", argv, 4, 0, 0))
+
+	// no such argument
+
+	stegol.CheckStringEqual(t, "m", clasp.FormatDiagnosticAt("m", argv, 5, 0, 0))
+	stegol.CheckStringEqual(t, "m", clasp.FormatDiagnosticAt("m", argv, 0, 0, 0))
+}
+
+func Test_UnrecognisedArgumentError_Diagnostic(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "-xaz", "--levle=3"}, clasp.ParseParams{Specifications: diagnostic_specifications()})
+
+	err := args.UnusedFlagsAndOptionsError()
+
+	require.NotNil(t, err)
+
+	var diagnostics []string
+
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+
+		var uae *clasp.UnrecognisedArgumentError
+
+		require.True(t, errors.As(e, &uae))
+
+		diagnostics = append(diagnostics, uae.Diagnostic())
+	}
+
+	// the first character of the apparent compound flag that is not a flag
+	// is indicated
+
+	require.Equal(t, []string{
+
+		"" +
+			"myprog: unrecognised flag/option: -xaz\n" +
+			"    myprog -xaz --levle=3\n" +
+			"             ^",
+		"" +
+			"myprog: unrecognised flag/option: --levle=3; did you mean --level?\n" +
+			"    myprog -xaz --levle=3\n" +
+			"                ^~~~~~~~~",
+	}, diagnostics)
+}
+
+func Test_UnexpectedValueError_Diagnostic(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "in", "extra"}, clasp.ParseParams{})
+
+	args.Values[0].Use()
+
+	var uve *clasp.UnexpectedValueError
+
+	require.True(t, errors.As(args.UnusedValuesError(), &uve))
+
+	stegol.CheckStringEqual(t, ""+
+		"myprog: unexpected value: extra\n"+
+		"    myprog in extra\n"+
+		"              ^~~~~",
+		uve.Diagnostic())
+}
+
+func Test_VerifyAllFlagsAndOptionsUsed_ShowDiagnostics(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "-xqz"}, clasp.ParseParams{Specifications: diagnostic_specifications()})
+
+	args.FlagIsSpecified("--extract")
+	args.FlagIsSpecified("--gzip")
+
+	var stream bytes.Buffer
+
+	rc, err := args.VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{
+
+		Stream:     &stream,
+		UsageFlags: clasp.Usage_ShowDiagnostics | clasp.DontCallExit,
+	})
+
+	require.Equal(t, 1, rc)
+	require.NotNil(t, err)
+
+	stegol.CheckStringEqual(t, ""+
+		"myprog: unrecognised flag/option: --quiet\n"+
+		"    myprog -xqz\n"+
+		"             ^\n",
+		stream.String())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
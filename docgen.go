@@ -0,0 +1,544 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+type doc_value struct {
+	value   string
+	aliases []string
+}
+
+type doc_row struct {
+	anchor  string
+	name    string
+	aliases []string
+	help    string
+	values  []doc_value
+}
+
+type doc_group struct {
+	anchor string
+	name   string
+	rows   []doc_row
+}
+
+type doc_page struct {
+	program_name string
+	info_lines   []string
+	usage        string
+	groups       []doc_group
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func anchor_slug(s string) string {
+
+	var sb strings.Builder
+
+	pending_hyphen := false
+
+	for _, c := range strings.ToLower(s) {
+
+		if ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+
+			if pending_hyphen && 0 != sb.Len() {
+
+				sb.WriteByte('-')
+			}
+			pending_hyphen = false
+
+			sb.WriteRune(c)
+		} else {
+
+			pending_hyphen = true
+		}
+	}
+
+	return sb.String()
+}
+
+// Obtains the given anchor ID, made unique within the page by appending
+// "-2", "-3", etc. if it has already been used.
+func unique_anchor(used map[string]bool, id string) string {
+
+	unique := id
+
+	for n := 2; used[unique]; n++ {
+
+		unique = fmt.Sprintf("%s-%d", id, n)
+	}
+
+	used[unique] = true
+
+	return unique
+}
+
+func build_doc_page(specifications []Specification, params UsageParams, apiFunctionName string) (page doc_page, err error) {
+
+	for i := range specifications {
+
+		if err = specification_type_error(specifications, i); err != nil {
+
+			return
+		}
+	}
+
+	specifications = presented_specifications(specifications, params)
+
+	page.program_name = get_program_name(params)
+
+	if page.info_lines, err = expand_info_lines(params, apiFunctionName); err != nil {
+
+		return
+	}
+
+	flags_and_options_string := params.FlagsAndOptionsString
+	if "" == flags_and_options_string && 0 != len(specifications) {
+
+		flags_and_options_string = usage_message(params, Message_FlagsAndOptionsPlaceholder)
+	}
+
+	page.usage = page.program_name
+	if "" != strings.TrimSpace(flags_and_options_string) {
+
+		page.usage += " " + flags_and_options_string
+	}
+	if "" != params.ValuesString {
+
+		page.usage += " " + params.ValuesString
+	}
+
+	groups, value_aliases := group_specifications(specifications)
+
+	used_anchors := make(map[string]bool)
+
+	for _, group := range groups {
+
+		dg := doc_group{name: group.name}
+
+		if "" != group.name {
+
+			dg.anchor = unique_anchor(used_anchors, AnchorID(Section(group.name)))
+		}
+
+		for _, a := range group.specifications {
+
+			row := doc_row{
+
+				anchor: unique_anchor(used_anchors, AnchorID(a)),
+				name:   a.Name,
+				help:   a.Help,
+			}
+
+			switch a.Type {
+
+			case FlagType:
+
+				row.aliases = a.Aliases
+			case OptionType:
+
+				row.name += "=<value>"
+
+				for _, c := range value_aliases_outside_value_set(a, value_aliases[a.Name]) {
+
+					for _, b := range c.Aliases {
+
+						row.aliases = append(row.aliases, b+" "+c.Name)
+					}
+				}
+				for _, b := range a.Aliases {
+
+					row.aliases = append(row.aliases, b+" <value>")
+				}
+			}
+
+			for _, value := range a.ValueSet {
+
+				dv := doc_value{value: value}
+
+				for _, c := range value_aliases[a.Name] {
+
+					if c.Name == a.Name+"="+value {
+
+						dv.aliases = append(dv.aliases, c.Aliases...)
+					}
+				}
+
+				row.values = append(row.values, dv)
+			}
+
+			dg.rows = append(dg.rows, row)
+		}
+
+		page.groups = append(page.groups, dg)
+	}
+
+	return
+}
+
+// Escapes text for a Markdown table cell, such that
+// backslashes, pipes, and characters that would otherwise be taken as
+// HTML - e.g. "<file>" - are rendered literally, and each newline, which
+// would otherwise end the table row, is rendered as a line break.
+func markdown_cell(s string) string {
+
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+
+	return s
+}
+
+// Escapes text for a Markdown heading or paragraph, such that it is
+// rendered literally: characters that would otherwise be taken as emphasis,
+// code, links, or HTML are escaped, as is any character at the start of a
+// line that would otherwise begin a heading or list, and
+// each newline is rendered as a line break.
+func markdown_text(s string) string {
+
+	var sb strings.Builder
+
+	for i, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+
+		if 0 != i {
+
+			sb.WriteString("<br>")
+		}
+
+		// the index of the character that would begin a block, if any
+
+		block_marker := -1
+
+		if n := len(line) - len(strings.TrimLeft(line, "0123456789")); 0 != n && n < len(line) && ('.' == line[n] || ')' == line[n]) {
+
+			block_marker = n
+		} else if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+") || strings.HasPrefix(line, "=") {
+
+			block_marker = 0
+		}
+
+		for j, c := range line {
+
+			switch c {
+
+			case '&':
+
+				sb.WriteString("&amp;")
+			case '<':
+
+				sb.WriteString("&lt;")
+			case '>':
+
+				sb.WriteString("&gt;")
+			case '\\', '`', '*', '_', '[', ']', '|', '~':
+
+				sb.WriteByte('\\')
+				sb.WriteRune(c)
+			default:
+
+				if j == block_marker {
+
+					sb.WriteByte('\\')
+				}
+				sb.WriteRune(c)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// Obtains a run of backticks that is longer than any in the given text,
+// and at least as long as the given minimum, so that it may delimit the
+// text as a code span or fenced code block.
+func markdown_backticks(s string, minimum int) string {
+
+	longest, run := 0, 0
+
+	for _, c := range s {
+
+		if '`' == c {
+
+			run++
+
+			longest = max(longest, run)
+		} else {
+
+			run = 0
+		}
+	}
+
+	return strings.Repeat("`", max(minimum, longest+1))
+}
+
+// Renders text as a Markdown code span, delimited by a run of backticks
+// longer than any it contains, and padded with spaces if it starts or ends
+// with a backtick (or starts and ends with a space), which would otherwise
+// be taken as part of the delimiters (or stripped).
+func markdown_code(s string) string {
+
+	delimiter := markdown_backticks(s, 1)
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") || (strings.HasPrefix(s, " ") && strings.HasSuffix(s, " ") && "" != strings.TrimSpace(s)) {
+
+		s = " " + s + " "
+	}
+
+	return delimiter + strings.ReplaceAll(s, "|", `\|`) + delimiter
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains the stable anchor ID for the given specification that is used
+// in the reference pages generated by [GenerateMarkdown] and
+// [GenerateHTML], so that other pages may link to a specific flag, option,
+// or section.
+//
+// The ID of a flag or option is "opt-" followed by its name, and the ID of
+// a section is "section-" followed by its name, in each case lower-cased
+// and with each run of characters other than letters and digits replaced
+// by a single hyphen, and leading/trailing hyphens removed. For example,
+// the ID of `--dry-run` is "opt-dry-run", and the ID of `behaviour:` is
+// "section-behaviour".
+//
+// Distinct names may obtain the same ID - e.g. `--dry-run` and
+// `--dry_run`, or `-v` and `--v` - in which case the generated pages give
+// the first such specification, in order of specification, this ID and
+// each subsequent one this ID followed by "-2", "-3", etc., so that every
+// anchor in a page is unique. Such suffixed IDs therefore depend on the
+// order of the specifications - reordering them, or adding or removing a
+// specification whose ID is the same, changes which specification is
+// given which - and only the IDs of specifications whose IDs are distinct
+// are stable.
+func AnchorID(specification Specification) string {
+
+	switch specification.Type {
+
+	case SectionType:
+
+		return "section-" + anchor_slug(specification.Name)
+	default:
+
+		return "opt-" + anchor_slug(specification.Name)
+	}
+}
+
+// Writes to the given stream a reference page, in Markdown format,
+// describing the program according to the given specifications and
+// parameters.
+//
+// The page has a heading containing the program name, followed by the info
+// lines and the usage, and then a table of the flags and options, with
+// anchored headings for each section (see [AnchorID]).
+func GenerateMarkdown(specifications []Specification, params UsageParams, w io.Writer) error {
+
+	page, err := build_doc_page(specifications, params, "GenerateMarkdown")
+	if err != nil {
+
+		return err
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", markdown_text(page.program_name))
+
+	if 0 != len(page.info_lines) {
+
+		for _, info_line := range page.info_lines {
+
+			fmt.Fprintf(&sb, "%s\n", markdown_text(info_line))
+		}
+		fmt.Fprintf(&sb, "\n")
+	}
+
+	fence := markdown_backticks(page.usage, 3)
+
+	fmt.Fprintf(&sb, "## Usage\n\n%[1]s\n%[2]s\n%[1]s\n", fence, page.usage)
+
+	if 0 != len(page.groups) {
+
+		fmt.Fprintf(&sb, "\n## Flags and options\n")
+
+		for _, group := range page.groups {
+
+			fmt.Fprintf(&sb, "\n")
+
+			if "" != group.name {
+
+				fmt.Fprintf(&sb, "### <a id=\"%s\"></a>%s\n\n", group.anchor, markdown_text(group.name))
+			}
+
+			if 0 == len(group.rows) {
+
+				continue
+			}
+
+			fmt.Fprintf(&sb, "| Name | Aliases | Help | Allowed values |\n")
+			fmt.Fprintf(&sb, "| ---- | ------- | ---- | -------------- |\n")
+
+			for _, row := range group.rows {
+
+				aliases := make([]string, len(row.aliases))
+
+				for i, alias := range row.aliases {
+
+					aliases[i] = markdown_code(alias)
+				}
+
+				values := make([]string, len(row.values))
+
+				for i, value := range row.values {
+
+					values[i] = markdown_code(value.value)
+
+					if 0 != len(value.aliases) {
+
+						value_aliases := make([]string, len(value.aliases))
+
+						for j, alias := range value.aliases {
+
+							value_aliases[j] = markdown_code(alias)
+						}
+
+						values[i] += " (" + strings.Join(value_aliases, ", ") + ")"
+					}
+				}
+
+				fmt.Fprintf(&sb, "| <a id=\"%s\"></a>%s | %s | %s | %s |\n", row.anchor, markdown_code(row.name), strings.Join(aliases, ", "), markdown_cell(row.help), strings.Join(values, ", "))
+			}
+		}
+	}
+
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+// Writes to the given stream a reference page, as an HTML document,
+// describing the program according to the given specifications and
+// parameters.
+//
+// The content is as described for [GenerateMarkdown], with the anchor ID
+// (see [AnchorID]) of each section given to its heading and of each flag
+// and option given to its table row.
+func GenerateHTML(specifications []Specification, params UsageParams, w io.Writer) error {
+
+	page, err := build_doc_page(specifications, params, "GenerateHTML")
+	if err != nil {
+
+		return err
+	}
+
+	var sb strings.Builder
+
+	program_name := html.EscapeString(page.program_name)
+
+	fmt.Fprintf(&sb, "<!DOCTYPE html>\n")
+	fmt.Fprintf(&sb, "<html>\n")
+	fmt.Fprintf(&sb, "<head>\n")
+	fmt.Fprintf(&sb, "<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", program_name)
+	fmt.Fprintf(&sb, "</head>\n")
+	fmt.Fprintf(&sb, "<body>\n")
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", program_name)
+
+	for _, info_line := range page.info_lines {
+
+		if "" != info_line {
+
+			fmt.Fprintf(&sb, "<p>%s</p>\n", html.EscapeString(info_line))
+		}
+	}
+
+	fmt.Fprintf(&sb, "<h2>Usage</h2>\n")
+	fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(page.usage))
+
+	if 0 != len(page.groups) {
+
+		fmt.Fprintf(&sb, "<h2>Flags and options</h2>\n")
+
+		for _, group := range page.groups {
+
+			if "" != group.name {
+
+				fmt.Fprintf(&sb, "<h3 id=\"%s\">%s</h3>\n", group.anchor, html.EscapeString(group.name))
+			}
+
+			if 0 == len(group.rows) {
+
+				continue
+			}
+
+			fmt.Fprintf(&sb, "<table>\n")
+			fmt.Fprintf(&sb, "<tr><th>Name</th><th>Aliases</th><th>Help</th><th>Allowed values</th></tr>\n")
+
+			for _, row := range group.rows {
+
+				aliases := make([]string, len(row.aliases))
+
+				for i, alias := range row.aliases {
+
+					aliases[i] = "<code>" + html.EscapeString(alias) + "</code>"
+				}
+
+				values := make([]string, len(row.values))
+
+				for i, value := range row.values {
+
+					values[i] = "<code>" + html.EscapeString(value.value) + "</code>"
+
+					if 0 != len(value.aliases) {
+
+						value_aliases := make([]string, len(value.aliases))
+
+						for j, alias := range value.aliases {
+
+							value_aliases[j] = "<code>" + html.EscapeString(alias) + "</code>"
+						}
+
+						values[i] += " (" + strings.Join(value_aliases, ", ") + ")"
+					}
+				}
+
+				fmt.Fprintf(&sb, "<tr id=\"%s\"><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>\n", row.anchor, html.EscapeString(row.name), strings.Join(aliases, ", "), html.EscapeString(row.help), strings.Join(values, ", "))
+			}
+
+			fmt.Fprintf(&sb, "</table>\n")
+		}
+	}
+
+	fmt.Fprintf(&sb, "</body>\n")
+	fmt.Fprintf(&sb, "</html>\n")
+
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
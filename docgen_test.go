@@ -0,0 +1,251 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func docgen_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Flag("--dry-run").SetHelp("Does nothing | really"),
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+	}
+}
+
+func docgen_params() clasp.UsageParams {
+
+	return clasp.UsageParams{
+
+		ProgramName:  "myprog",
+		Version:      "1.2.3",
+		ValuesString: "<path>",
+		InfoLines: []string{
+
+			"CLASP.Go Test Suite",
+			":version:",
+		},
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_AnchorID(t *testing.T) {
+
+	require.Equal(t, "opt-dry-run", clasp.AnchorID(clasp.Flag("--dry-run")))
+	require.Equal(t, "opt-verbosity", clasp.AnchorID(clasp.Option("--verbosity")))
+	require.Equal(t, "opt-verbosity-chatty", clasp.AnchorID(clasp.Flag("--verbosity=chatty")))
+	require.Equal(t, "opt-x", clasp.AnchorID(clasp.Flag("-x")))
+	require.Equal(t, "section-behaviour", clasp.AnchorID(clasp.Section("behaviour:")))
+	require.Equal(t, "section-standard-flags", clasp.AnchorID(clasp.Section("Standard  Flags:")))
+}
+
+func Test_GenerateMarkdown(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateMarkdown(docgen_specifications(), docgen_params(), buf)
+
+	require.Nil(t, err)
+
+	expected := "# myprog\n" +
+		"\n" +
+		"CLASP.Go Test Suite\n" +
+		"myprog 1.2.3\n" +
+		"\n" +
+		"## Usage\n" +
+		"\n" +
+		"```\n" +
+		"myprog [ ... flags and options ... ] <path>\n" +
+		"```\n" +
+		"\n" +
+		"## Flags and options\n" +
+		"\n" +
+		"| Name | Aliases | Help | Allowed values |\n" +
+		"| ---- | ------- | ---- | -------------- |\n" +
+		"| <a id=\"opt-dry-run\"></a>`--dry-run` |  | Does nothing \\| really |  |\n" +
+		"\n" +
+		"### <a id=\"section-behaviour\"></a>behaviour:\n" +
+		"\n" +
+		"| Name | Aliases | Help | Allowed values |\n" +
+		"| ---- | ------- | ---- | -------------- |\n" +
+		"| <a id=\"opt-verbose\"></a>`--verbose` | `-v` | Makes output verbose |  |\n" +
+		"| <a id=\"opt-verbosity\"></a>`--verbosity=<value>` | `-V <value>` | Specifies the verbosity | `terse`, `chatty` (`-c`) |\n" +
+		"\n" +
+		"### <a id=\"section-standard\"></a>standard:\n" +
+		"\n" +
+		"| Name | Aliases | Help | Allowed values |\n" +
+		"| ---- | ------- | ---- | -------------- |\n" +
+		"| <a id=\"opt-help\"></a>`--help` |  | Shows this help and exits |  |\n"
+
+	stegol.CheckStringEqual(t, expected, buf.String())
+}
+
+func Test_GenerateHTML(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateHTML(docgen_specifications(), docgen_params(), buf)
+
+	require.Nil(t, err)
+
+	expected := "<!DOCTYPE html>\n" +
+		"<html>\n" +
+		"<head>\n" +
+		"<meta charset=\"utf-8\">\n" +
+		"<title>myprog</title>\n" +
+		"</head>\n" +
+		"<body>\n" +
+		"<h1>myprog</h1>\n" +
+		"<p>CLASP.Go Test Suite</p>\n" +
+		"<p>myprog 1.2.3</p>\n" +
+		"<h2>Usage</h2>\n" +
+		"<pre>myprog [ ... flags and options ... ] &lt;path&gt;</pre>\n" +
+		"<h2>Flags and options</h2>\n" +
+		"<table>\n" +
+		"<tr><th>Name</th><th>Aliases</th><th>Help</th><th>Allowed values</th></tr>\n" +
+		"<tr id=\"opt-dry-run\"><td><code>--dry-run</code></td><td></td><td>Does nothing | really</td><td></td></tr>\n" +
+		"</table>\n" +
+		"<h3 id=\"section-behaviour\">behaviour:</h3>\n" +
+		"<table>\n" +
+		"<tr><th>Name</th><th>Aliases</th><th>Help</th><th>Allowed values</th></tr>\n" +
+		"<tr id=\"opt-verbose\"><td><code>--verbose</code></td><td><code>-v</code></td><td>Makes output verbose</td><td></td></tr>\n" +
+		"<tr id=\"opt-verbosity\"><td><code>--verbosity=&lt;value&gt;</code></td><td><code>-V &lt;value&gt;</code></td><td>Specifies the verbosity</td><td><code>terse</code>, <code>chatty</code> (<code>-c</code>)</td></tr>\n" +
+		"</table>\n" +
+		"<h3 id=\"section-standard\">standard:</h3>\n" +
+		"<table>\n" +
+		"<tr><th>Name</th><th>Aliases</th><th>Help</th><th>Allowed values</th></tr>\n" +
+		"<tr id=\"opt-help\"><td><code>--help</code></td><td></td><td>Shows this help and exits</td><td></td></tr>\n" +
+		"</table>\n" +
+		"</body>\n" +
+		"</html>\n"
+
+	stegol.CheckStringEqual(t, expected, buf.String())
+}
+
+func Test_GenerateMarkdown_escapes_help(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateMarkdown([]clasp.Specification{
+
+		clasp.Option("--input").SetHelp("Reads <file> & writes\nthe results"),
+	}, clasp.UsageParams{ProgramName: "myprog"}, buf)
+
+	require.Nil(t, err)
+	require.Contains(t, buf.String(), "| <a id=\"opt-input\"></a>`--input=<value>` |  | Reads &lt;file&gt; &amp; writes<br>the results |  |\n")
+}
+
+func Test_GenerateMarkdown_escapes_heading_and_info_lines(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateMarkdown(nil, clasp.UsageParams{
+
+		ProgramName: "my_*prog*",
+		InfoLines:   []string{"# not a heading", "1. not a list", "uses <tags> & [links](x)\nover lines"},
+	}, buf)
+
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), "# my\\_\\*prog\\*\n\n\\# not a heading\n1\\. not a list\nuses &lt;tags&gt; &amp; \\[links\\](x)<br>over lines\n\n"))
+}
+
+func Test_GenerateMarkdown_backticks_in_code(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateMarkdown([]clasp.Specification{
+
+		clasp.Option("--quote").SetAlias("-`").SetValues("`", "a``b"),
+	}, clasp.UsageParams{ProgramName: "myprog", ValuesString: "```"}, buf)
+
+	require.Nil(t, err)
+	require.Contains(t, buf.String(), "\n````\nmyprog [ ... flags and options ... ] ```\n````\n")
+	require.Contains(t, buf.String(), "| <a id=\"opt-quote\"></a>`--quote=<value>` | ``-` <value>`` |  | `` ` ``, ```a``b``` |\n")
+}
+
+func Test_GenerateMarkdown_and_GenerateHTML_value_aliases_outside_value_set(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Option("--mode").SetAlias("-m"),
+		clasp.Flag("--mode=fast").SetAlias("-f"),
+	}
+
+	buf := new(bytes.Buffer)
+
+	require.Nil(t, clasp.GenerateMarkdown(specifications, clasp.UsageParams{ProgramName: "myprog"}, buf))
+	require.Contains(t, buf.String(), "| <a id=\"opt-mode\"></a>`--mode=<value>` | `-f --mode=fast`, `-m <value>` |  |  |\n")
+
+	buf.Reset()
+
+	require.Nil(t, clasp.GenerateHTML(specifications, clasp.UsageParams{ProgramName: "myprog"}, buf))
+	require.Contains(t, buf.String(), "<tr id=\"opt-mode\"><td><code>--mode=&lt;value&gt;</code></td><td><code>-f --mode=fast</code>, <code>-m &lt;value&gt;</code></td><td></td><td></td></tr>\n")
+}
+
+func Test_GenerateMarkdown_and_GenerateHTML_unique_anchors(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--dry-run"),
+		clasp.Flag("--dry_run"),
+		clasp.Flag("--dry-run-2"),
+		clasp.Flag("-v"),
+		clasp.Flag("--v"),
+	}
+
+	md := new(bytes.Buffer)
+
+	require.Nil(t, clasp.GenerateMarkdown(specifications, clasp.UsageParams{ProgramName: "myprog"}, md))
+
+	for _, id := range []string{"opt-dry-run", "opt-dry-run-2", "opt-dry-run-2-2", "opt-v", "opt-v-2"} {
+
+		require.Equal(t, 1, strings.Count(md.String(), "<a id=\""+id+"\">"), id)
+	}
+
+	require.Contains(t, md.String(), "<a id=\"opt-dry-run-2\"></a>`--dry_run`")
+	require.Contains(t, md.String(), "<a id=\"opt-dry-run-2-2\"></a>`--dry-run-2`")
+
+	h := new(bytes.Buffer)
+
+	require.Nil(t, clasp.GenerateHTML(specifications, clasp.UsageParams{ProgramName: "myprog"}, h))
+
+	require.Contains(t, h.String(), "<tr id=\"opt-v-2\"><td><code>--v</code></td>")
+}
+
+func Test_GenerateMarkdown_rejects_value_specification(t *testing.T) {
+
+	err := clasp.GenerateMarkdown([]clasp.Specification{{Type: clasp.ValueType}}, clasp.UsageParams{}, new(bytes.Buffer))
+
+	require.Equal(t, `specification[0] (""): is of type Value, but must be of type Flag, Option, or Section`, err.Error())
+}
+
+func Test_GenerateMarkdown_no_specifications(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateMarkdown(nil, clasp.UsageParams{ProgramName: "myprog"}, buf)
+
+	require.Nil(t, err)
+
+	stegol.CheckStringEqual(t, "# myprog\n\n## Usage\n\n```\nmyprog\n```\n", buf.String())
+}
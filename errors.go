@@ -0,0 +1,239 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Error that reports a flag/option argument that was not recognised, i.e.
+// that was not used (see [Argument.Use]) by the program.
+type UnrecognisedArgumentError struct {
+	ProgramName string    // The program name.
+	Argument    *Argument // The unrecognised argument.
+	Suggestions []string  // The suggestions for the argument (see [Arguments.Suggest]), if any.
+
+	message     string
+	argv        []string
+	char_offset int
+	char_length int
+}
+
+func (e *UnrecognisedArgumentError) Error() string {
+
+	return e.message
+}
+
+// Renders the error as a diagnostic (see [FormatDiagnosticAt]) that
+// indicates the unrecognised argument within the command line, or, for an
+// apparent compound flag, the first character that is not a flag.
+func (e *UnrecognisedArgumentError) Diagnostic() string {
+
+	return FormatDiagnosticAt(e.message, e.argv, e.Argument.CmdLineIndex, e.char_offset, e.char_length)
+}
+
+// Error that reports a value argument that was not expected, i.e. that was
+// not used (see [Argument.Use]) by the program.
+type UnexpectedValueError struct {
+	ProgramName string    // The program name.
+	Argument    *Argument // The unexpected argument.
+
+	message string
+	argv    []string
+}
+
+func (e *UnexpectedValueError) Error() string {
+
+	return e.message
+}
+
+// Renders the error as a diagnostic (see [FormatDiagnosticAt]) that
+// indicates the unexpected value within the command line.
+func (e *UnexpectedValueError) Diagnostic() string {
+
+	return FormatDiagnosticAt(e.message, e.argv, e.Argument.CmdLineIndex, 0, 0)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func (args *Arguments) unrecognised_argument_errors(program_name string, catalogue MessageCatalogue, locale string) []error {
+
+	var errs []error
+
+	message_template := lookup_message(catalogue, locale, Message_UnrecognisedFlagOrOption)
+
+	for _, arg := range args.GetUnusedFlagsAndOptions() {
+
+		suggestions := args.Suggest(arg)
+
+		message := substitute_arguments(message_template, program_name, arg.Str())
+
+		if hint := format_suggestion_hint(catalogue, locale, suggestions); "" != hint {
+
+			message += "; " + hint
+		}
+
+		offset, length := args.unrecognised_span(arg)
+
+		errs = append(errs, &UnrecognisedArgumentError{
+
+			ProgramName: program_name,
+			Argument:    arg,
+			Suggestions: suggestions,
+
+			message:     message,
+			argv:        args.Argv,
+			char_offset: offset,
+			char_length: length,
+		})
+	}
+
+	return errs
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains an error that reports each unused flag and option argument
+// (see [Arguments.GetUnusedFlagsAndOptions]) as an
+// [UnrecognisedArgumentError], with a message in the locale specified to
+// [Parse] (see [ParseParams.Locale]) that includes any suggestions (see
+// [Arguments.SuggestionHint]), or `nil` if there are none.
+//
+// The error, if not `nil`, may be examined via [errors.As], and unwrapped
+// into its individual errors via its `Unwrap() []error` method.
+func (args *Arguments) UnusedFlagsAndOptionsError() error {
+
+	return errors.Join(args.unrecognised_argument_errors(args.ProgramName, args.messages, args.locale)...)
+}
+
+// Obtains an error that reports each unused value argument (see
+// [Arguments.GetUnusedValues]) as an [UnexpectedValueError], with a
+// message in the locale specified to [Parse] (see [ParseParams.Locale]), or
+// `nil` if there are none.
+//
+// The error, if not `nil`, may be examined via [errors.As], and unwrapped
+// into its individual errors via its `Unwrap() []error` method.
+func (args *Arguments) UnusedValuesError() error {
+
+	var errs []error
+
+	message_template := lookup_message(args.messages, args.locale, Message_UnexpectedValue)
+
+	for _, arg := range args.GetUnusedValues() {
+
+		errs = append(errs, &UnexpectedValueError{
+
+			ProgramName: args.ProgramName,
+			Argument:    arg,
+
+			message: substitute_arguments(message_template, args.ProgramName, arg.Value),
+			argv:    args.Argv,
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// Verifies that all flag and option arguments have been used (see
+// [Argument.Use]), which is typically done once the program has looked up
+// all the flags and options it recognises. If not, each unused argument is
+// reported - as described for [Arguments.UnusedFlagsAndOptionsError] - on a
+// separate line to the stream (or, if [Usage_ShowDiagnostics] is specified,
+// as a diagnostic; see [UnrecognisedArgumentError.Diagnostic]), followed,
+// if [Usage_ShowHelpHint] is specified, by the hint "<program>: use --help
+// for usage", and then the exiter is called with the exit code, in the same
+// manner as [ShowUsage].
+//
+// The following members of params are interpreted differently than by
+// [ShowUsage]:
+//
+//   - Stream: if `nil`, [os.Stderr] is used;
+//   - ProgramName: if empty, [Arguments.ProgramName] is used;
+//   - ExitCode: if 0, 1 is used;
+//   - Messages, Locale: if both are unspecified, those specified to
+//     [Parse] are used.
+//
+// If all arguments have been used, nothing is written, the exiter is not
+// called, and 0 and `nil` are returned; otherwise, if the exiter returns,
+// the exit code and the error obtained from
+// [Arguments.UnusedFlagsAndOptionsError] are returned.
+func (args *Arguments) VerifyAllFlagsAndOptionsUsed(params UsageParams) (rc int, err error) {
+
+	program_name := params.ProgramName
+	if "" == program_name {
+
+		program_name = args.ProgramName
+	}
+
+	catalogue, locale := params.Messages, params.Locale
+	if nil == catalogue && "" == locale {
+
+		catalogue, locale = args.messages, args.locale
+	}
+
+	errs := args.unrecognised_argument_errors(program_name, catalogue, locale)
+
+	if 0 == len(errs) {
+
+		return 0, nil
+	}
+
+	if nil == params.Stream {
+
+		params.Stream = os.Stderr
+	}
+
+	if 0 == params.ExitCode {
+
+		params.ExitCode = 1
+	}
+
+	exiter := params.Exiter
+	if nil == exiter {
+
+		exiter = new(default_exiter)
+	}
+
+	for _, e := range errs {
+
+		if 0 != (Usage_ShowDiagnostics & params.UsageFlags) {
+
+			fmt.Fprintf(params.Stream, "%s\n", e.(*UnrecognisedArgumentError).Diagnostic())
+		} else {
+
+			fmt.Fprintf(params.Stream, "%s\n", e.Error())
+		}
+	}
+
+	if 0 != (Usage_ShowHelpHint & params.UsageFlags) {
+
+		fmt.Fprintf(params.Stream, "%s\n", substitute_arguments(lookup_message(catalogue, locale, Message_UseHelpForUsage), program_name, HelpFlag().Name))
+	}
+
+	if should_call_Exit(params) {
+
+		exiter.Exit(params.ExitCode)
+	}
+
+	return params.ExitCode, errors.Join(errs...)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
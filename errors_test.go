@@ -0,0 +1,160 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	"github.com/synesissoftware/CLASP.Go/clasptest"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func verify_arguments(argv ...string) *clasp.Arguments {
+
+	args := clasp.Parse(append([]string{"myprog"}, argv...), clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--verbose").SetAlias("-v"),
+			clasp.HelpFlag(),
+		},
+	})
+
+	args.FlagIsSpecified(clasp.Flag("--verbose"))
+
+	return args
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_VerifyAllFlagsAndOptionsUsed_all_used(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+	exiter := new(clasptest.RecordingExiter)
+
+	rc, err := verify_arguments("-v", "value").VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{
+
+		Stream: stream,
+		Exiter: exiter,
+	})
+
+	require.Equal(t, 0, rc)
+	require.Nil(t, err)
+	require.False(t, exiter.Exited)
+	require.Equal(t, "", stream.String())
+}
+
+func Test_VerifyAllFlagsAndOptionsUsed_reports_all(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+	exiter := new(clasptest.RecordingExiter)
+
+	rc, err := verify_arguments("--verbsoe", "-v", "--colour=red", "value").VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{
+
+		Stream: stream,
+		Exiter: exiter,
+	})
+
+	expected := "myprog: unrecognised flag/option: --verbsoe; did you mean --verbose?\n" +
+		"myprog: unrecognised flag/option: --colour=red\n"
+
+	stegol.CheckStringEqual(t, expected, stream.String())
+
+	require.Equal(t, 1, rc)
+	require.True(t, exiter.Exited)
+	require.Equal(t, 1, exiter.ExitCode)
+
+	var uae *clasp.UnrecognisedArgumentError
+
+	require.True(t, errors.As(err, &uae))
+	require.Equal(t, "--verbsoe", uae.Argument.GivenName)
+}
+
+func Test_VerifyAllFlagsAndOptionsUsed_help_hint(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+	exiter := new(clasptest.RecordingExiter)
+
+	rc, err := verify_arguments("-x").VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "prog",
+		UsageFlags:  clasp.Usage_ShowHelpHint,
+		ExitCode:    2,
+		Exiter:      exiter,
+	})
+
+	expected := "prog: unrecognised flag/option: -x\n" +
+		"prog: use --help for usage\n"
+
+	stegol.CheckStringEqual(t, expected, stream.String())
+
+	require.Equal(t, 2, rc)
+	require.NotNil(t, err)
+	require.Equal(t, 2, exiter.ExitCode)
+}
+
+func Test_VerifyAllFlagsAndOptionsUsed_DontCallExit(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+	exiter := new(clasptest.RecordingExiter)
+
+	rc, err := verify_arguments("-x").VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{
+
+		Stream:     stream,
+		UsageFlags: clasp.DontCallExit,
+		Exiter:     exiter,
+		Locale:     "de",
+	})
+
+	require.Equal(t, "myprog: unbekannter Schalter bzw. unbekannte Option: -x\n", stream.String())
+	require.Equal(t, 1, rc)
+	require.NotNil(t, err)
+	require.False(t, exiter.Exited)
+}
+
+func Test_UnusedValuesError(t *testing.T) {
+
+	args := verify_arguments("-v", "in.txt", "out.txt", "extra")
+
+	require.Equal(t, 3, len(args.GetUnusedValues()))
+
+	args.Values[0].Use()
+	args.Values[1].Use()
+
+	err := args.UnusedValuesError()
+
+	require.NotNil(t, err)
+	stegol.CheckStringEqual(t, "myprog: unexpected value: extra", err.Error())
+
+	var uve *clasp.UnexpectedValueError
+
+	require.True(t, errors.As(err, &uve))
+	require.Equal(t, "extra", uve.Argument.Value)
+	require.Equal(t, "myprog", uve.ProgramName)
+
+	// values are not reported as unrecognised flags/options
+
+	require.Nil(t, args.UnusedFlagsAndOptionsError())
+
+	args.Values[2].Use()
+
+	require.Nil(t, args.UnusedValuesError())
+}
+
+func Test_UnusedValuesError_German(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "extra"}, clasp.ParseParams{Locale: "de_DE"})
+
+	stegol.CheckStringEqual(t, "myprog: unerwarteter Wert: extra", args.UnusedValuesError().Error())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -69,12 +69,7 @@ func main() {
 
 	// Check for any unrecognised flags or options
 
-	if unused := args.GetUnusedFlagsAndOptions(); 0 != len(unused) {
-
-		fmt.Fprintf(os.Stderr, "%s: unrecognised flag/option: %s\n", args.ProgramName, unused[0].Str())
-
-		os.Exit(1)
-	}
+	args.VerifyAllFlagsAndOptionsUsed(clasp.UsageParams{UsageFlags: clasp.Usage_ShowHelpHint})
 
 	// Finish normal processing
 
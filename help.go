@@ -0,0 +1,256 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Error that reports a help topic (see [UsageParams.HelpTopic]) that
+// matches no section or flag/option.
+type NoHelpTopicError struct {
+	ProgramName string   // The program name.
+	Topic       string   // The help topic.
+	Suggestions []string // The suggested topics, if any.
+
+	message string
+}
+
+func (e *NoHelpTopicError) Error() string {
+
+	return e.message
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func section_topic(name string) string {
+
+	return strings.TrimSuffix(name, ":")
+}
+
+// Indicates whether the given flag/option name matches the given topic,
+// either exactly or ignoring leading hyphens.
+func name_matches_topic(name, topic string) bool {
+
+	if name == topic {
+
+		return true
+	}
+
+	t := strings.TrimLeft(topic, "-")
+
+	return "" != t && t == strings.TrimLeft(name, "-")
+}
+
+// Selects, from the given specifications, those of the section, or the
+// flag/option (along with its option-value aliases, e.g.
+// `--verbosity=chatty`), that matches the given topic, if any. A topic that
+// begins with a hyphen matches only a flag/option; otherwise a section
+// takes precedence.
+func select_help_topic(specifications []Specification, topic string) ([]Specification, bool) {
+
+	if !strings.HasPrefix(topic, "-") {
+
+		for i, specification := range specifications {
+
+			if SectionType == specification.Type && strings.EqualFold(section_topic(specification.Name), section_topic(topic)) {
+
+				selected := []Specification{specification}
+
+				for _, a := range specifications[i+1:] {
+
+					if SectionType == a.Type {
+
+						break
+					}
+
+					selected = append(selected, a)
+				}
+
+				return selected, true
+			}
+		}
+	}
+
+	for _, specification := range specifications {
+
+		switch specification.Type {
+
+		case FlagType, OptionType:
+
+			if strings.Contains(specification.Name, "=") {
+
+				continue
+			}
+
+			for _, name := range append([]string{specification.Name}, specification.Aliases...) {
+
+				if name_matches_topic(name, topic) {
+
+					selected := []Specification{specification}
+
+					for _, a := range specifications {
+
+						if strings.HasPrefix(a.Name, specification.Name+"=") {
+
+							selected = append(selected, a)
+						}
+					}
+
+					return selected, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func suggest_help_topics(specifications []Specification, topic string) []string {
+
+	var candidates []suggestion_candidate
+
+	add := func(suggestion string) {
+
+		if distance, ok := suggestion_distance(topic, suggestion); ok {
+
+			candidates = append(candidates, suggestion_candidate{suggestion, distance, len(candidates)})
+		}
+	}
+
+	for _, specification := range specifications {
+
+		switch specification.Type {
+
+		case SectionType:
+
+			add(section_topic(specification.Name))
+		case FlagType, OptionType:
+
+			if !strings.Contains(specification.Name, "=") {
+
+				add(specification.Name)
+			}
+		}
+	}
+
+	return rank_suggestions(candidates)
+}
+
+// Obtains the specifications that are to be shown by [ShowUsage]: those
+// presented (see [presented_specifications]) and, if a help topic is
+// specified, that match it, or an error if none do.
+func usage_specifications(specifications []Specification, params UsageParams) ([]Specification, error) {
+
+	specifications = presented_specifications(specifications, params)
+
+	if "" == params.HelpTopic {
+
+		return specifications, nil
+	}
+
+	if selected, found := select_help_topic(specifications, params.HelpTopic); found {
+
+		return selected, nil
+	}
+
+	program_name := get_program_name(params)
+	suggestions := suggest_help_topics(specifications, params.HelpTopic)
+
+	message := substitute_arguments(usage_message(params, Message_NoHelpTopic), program_name, params.HelpTopic)
+
+	if hint := format_suggestion_hint(params.Messages, params.Locale, suggestions); "" != hint {
+
+		message += "; " + hint
+	}
+
+	return nil, &NoHelpTopicError{
+
+		ProgramName: program_name,
+		Topic:       params.HelpTopic,
+		Suggestions: suggestions,
+
+		message: message,
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains, by value, a specification containing a stock specification of
+// a '--help-all' flag, which may be used to show usage that includes
+// advanced specifications (see [Specification.SetAdvanced]) by specifying
+// [Usage_ShowAll].
+func HelpAllFlag() Specification {
+
+	return Specification{Type: FlagType, Name: "--help-all", Help: "Shows help for all flags and options, including advanced ones, and exits"}
+}
+
+// Builder method that marks the specification as advanced, such that it
+// is omitted from usage (see [ShowUsage]) unless [Usage_ShowAll] is
+// specified - e.g. in response to the stock '--help-all' flag (see
+// [HelpAllFlag]) - or it is shown as, or within, a help topic (see
+// [UsageParams.HelpTopic]). Arguments are parsed, completed, and suggested
+// as normal.
+func (specification Specification) SetAdvanced() Specification {
+
+	specification.advanced = true
+
+	return specification
+}
+
+// Indicates whether the specification is advanced (see
+// [Specification.SetAdvanced]).
+func (specification Specification) IsAdvanced() bool {
+
+	return specification.advanced
+}
+
+// Looks for a request for help in the parsed arguments, being any of the
+// stock help flag (see [HelpFlag]) - "--help" - an option of the same name
+// that specifies a topic - e.g. "--help=output" - or the stock help-all
+// flag (see [HelpAllFlag]) - "--help-all". Any such argument found is
+// marked used.
+//
+// If found, the topic, if any, is to be given as [UsageParams.HelpTopic],
+// and, if all is `true`, [Usage_ShowAll] is to be specified.
+func (args *Arguments) LookupHelp() (topic string, all bool, found bool) {
+
+	if args.FlagIsSpecified(HelpAllFlag().Name) {
+
+		all = true
+		found = true
+	}
+
+	name := HelpFlag().Name
+
+	if args.FlagIsSpecified(name) {
+
+		found = true
+	}
+
+	if arg, ok := args.LookupOption(name); ok {
+
+		topic = arg.Value
+		found = true
+	}
+
+	return
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,244 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func help_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Flag("--trace-allocations").SetHelp("Traces allocations").SetAdvanced(),
+		clasp.Option("--verbosity").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+		clasp.Flag("--secret").SetHelp("Does secret things").SetHidden(),
+
+		clasp.Section("tuning:"),
+		clasp.Option("--cache-size").SetHelp("Specifies the cache size").SetAdvanced(),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+		clasp.HelpAllFlag(),
+	}
+}
+
+func show_help_(t *testing.T, flags clasp.UsageFlag, topic string) (string, error) {
+
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	_, err := clasp.ShowUsage(help_specifications(), clasp.UsageParams{
+
+		Stream:      &buf,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines | flags,
+		HelpTopic:   topic,
+	})
+
+	return buf.String(), err
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowUsage_omits_advanced(t *testing.T) {
+
+	usage, err := show_help_(t, 0, "")
+
+	require.NoError(t, err)
+
+	stegol.CheckStringEqual(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+	behaviour:
+
+	-v
+	--verbose
+		Makes output verbose
+	-c --verbosity=chatty
+	--verbosity=<value>
+		Specifies the verbosity
+		where <value> one of:
+			terse
+			chatty
+
+	standard:
+
+	--help
+		Shows this help and exits
+	--help-all
+		Shows help for all flags and options, including advanced ones, and exits
+`, usage)
+}
+
+func Test_ShowUsage_ShowAll_includes_advanced(t *testing.T) {
+
+	usage, err := show_help_(t, clasp.Usage_ShowAll, "")
+
+	require.NoError(t, err)
+	require.Contains(t, usage, "\t--trace-allocations\n\t\tTraces allocations\n")
+	require.Contains(t, usage, "\ttuning:\n\n\t--cache-size=<value>\n")
+
+	// hidden specifications are never shown
+
+	require.NotContains(t, usage, "--secret")
+}
+
+func Test_ShowUsage_HelpTopic_section(t *testing.T) {
+
+	for _, topic := range []string{"tuning", "tuning:", "TUNING"} {
+
+		usage, err := show_help_(t, 0, topic)
+
+		require.NoError(t, err)
+
+		// advanced specifications are shown within a topic
+
+		stegol.CheckStringEqual(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+	tuning:
+
+	--cache-size=<value>
+		Specifies the cache size
+`, usage)
+	}
+}
+
+func Test_ShowUsage_HelpTopic_flag(t *testing.T) {
+
+	for _, topic := range []string{"--verbosity", "verbosity"} {
+
+		usage, err := show_help_(t, 0, topic)
+
+		require.NoError(t, err)
+
+		stegol.CheckStringEqual(t, `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+	-c --verbosity=chatty
+	--verbosity=<value>
+		Specifies the verbosity
+		where <value> one of:
+			terse
+			chatty
+`, usage)
+	}
+
+	usage, err := show_help_(t, 0, "-v")
+
+	require.NoError(t, err)
+	require.Contains(t, usage, "\t-v\n\t--verbose\n\t\tMakes output verbose\n")
+	require.NotContains(t, usage, "behaviour:")
+}
+
+func Test_ShowUsage_no_help_topic(t *testing.T) {
+
+	usage, err := show_help_(t, 0, "--verbositty")
+
+	require.Empty(t, usage)
+	require.Error(t, err)
+	stegol.CheckStringEqual(t, "myprog: no help topic: --verbositty; did you mean --verbosity?", err.Error())
+
+	var nhte *clasp.NoHelpTopicError
+
+	require.True(t, errors.As(err, &nhte))
+	require.Equal(t, "--verbositty", nhte.Topic)
+	require.Equal(t, []string{"--verbosity"}, nhte.Suggestions)
+
+	_, err = show_help_(t, 0, "tunning")
+
+	stegol.CheckStringEqual(t, "myprog: no help topic: tunning; did you mean tuning?", err.Error())
+
+	_, err = show_help_(t, 0, "xyz")
+
+	stegol.CheckStringEqual(t, "myprog: no help topic: xyz", err.Error())
+}
+
+func Test_LookupHelp(t *testing.T) {
+
+	parse := func(argv ...string) *clasp.Arguments {
+
+		return clasp.Parse(append([]string{"myprog"}, argv...), clasp.ParseParams{Specifications: help_specifications()})
+	}
+
+	args := parse("-v")
+
+	_, _, found := args.LookupHelp()
+
+	require.False(t, found)
+
+	args = parse("--help")
+
+	topic, all, found := args.LookupHelp()
+
+	require.True(t, found)
+	require.False(t, all)
+	require.Equal(t, "", topic)
+	require.Empty(t, args.GetUnusedFlagsAndOptions())
+
+	args = parse("--help-all")
+
+	topic, all, found = args.LookupHelp()
+
+	require.True(t, found)
+	require.True(t, all)
+	require.Equal(t, "", topic)
+
+	args = parse("--help=tuning")
+
+	topic, all, found = args.LookupHelp()
+
+	require.True(t, found)
+	require.False(t, all)
+	require.Equal(t, "tuning", topic)
+	require.Empty(t, args.GetUnusedFlagsAndOptions())
+}
+
+func Test_HelpAllFlag_localised(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	_, err := clasp.ShowUsage([]clasp.Specification{clasp.HelpAllFlag()}, clasp.UsageParams{
+
+		Stream:      &buf,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit,
+		Locale:      "de",
+	})
+
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "Zeigt die Hilfe zu allen Schaltern und Optionen, einschließlich der erweiterten, an und beendet das Programm")
+}
+
+func Test_SaveSpecifications_advanced(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	require.NoError(t, clasp.SaveSpecifications(help_specifications(), &buf))
+	require.Contains(t, buf.String(), `"advanced": true`)
+
+	loaded, err := clasp.LoadSpecifications(&buf)
+
+	require.NoError(t, err)
+	require.False(t, loaded[1].IsAdvanced())
+	require.True(t, loaded[2].IsAdvanced())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
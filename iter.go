@@ -0,0 +1,154 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"iter"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func argument_name_from_id(id interface{}, apiFunctionName string, argType ArgType) string {
+
+	if s, is_string := id.(string); is_string {
+
+		return s
+	}
+
+	if spec, is_Specification := id.(Specification); is_Specification {
+
+		if argType != spec.Type {
+
+			panic(fmt.Sprintf("invoked %s() passing a non-%v Specification '%v'", apiFunctionName, argType, spec))
+		}
+
+		return spec.Name
+	}
+
+	panic(fmt.Sprintf("invoked %s() passing a value - '%v' - that is neither string nor specification", apiFunctionName, id))
+}
+
+func arguments_named(arguments []*Argument, name string) iter.Seq[*Argument] {
+
+	return func(yield func(*Argument) bool) {
+
+		for _, arg := range arguments {
+
+			if name == arg.ResolvedName {
+
+				arg.Use()
+
+				if !yield(arg) {
+
+					return
+				}
+			}
+		}
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains an iterator over all arguments, in command-line order. Arguments
+// are not marked used.
+//
+// NOTE: The iterator methods of [Arguments] observe the following rules with
+// respect to use (see [Argument.Use]):
+//
+//   - [Arguments.All], [Arguments.ValuesAfterDoubleHyphen], and
+//     [Arguments.Unused] do not mark arguments used, as they do not imply
+//     that an argument has been recognised;
+//   - [Arguments.FlagsNamed] and [Arguments.OptionsNamed] mark each
+//     argument used as it is yielded, in the same manner as
+//     [Arguments.LookupFlag] and [Arguments.LookupOption]; arguments not
+//     yielded, because iteration stopped early, are not marked.
+func (args *Arguments) All() iter.Seq[*Argument] {
+
+	return func(yield func(*Argument) bool) {
+
+		for _, arg := range args.Arguments {
+
+			if !yield(arg) {
+
+				return
+			}
+		}
+	}
+}
+
+// Obtains an iterator over all flag arguments matching the given flag -
+// specified either as `string` or [Specification] - e.g. each occurrence
+// of "-v" in "-v -v -v". Each argument is marked used as it is yielded.
+func (args *Arguments) FlagsNamed(id interface{}) iter.Seq[*Argument] {
+
+	return arguments_named(args.Flags, argument_name_from_id(id, "FlagsNamed", FlagType))
+}
+
+// Obtains an iterator over all option arguments matching the given
+// option - specified either as `string` or [Specification] - e.g. each
+// occurrence of "--include" in "--include=a --include=b". Each argument is
+// marked used as it is yielded.
+func (args *Arguments) OptionsNamed(id interface{}) iter.Seq[*Argument] {
+
+	return arguments_named(args.Options, argument_name_from_id(id, "OptionsNamed", OptionType))
+}
+
+// Obtains an iterator over all value arguments that follow the double
+// hyphen - "--" - argument, if any (see [Argument.AfterDoubleHyphen]).
+// Arguments are not marked used.
+func (args *Arguments) ValuesAfterDoubleHyphen() iter.Seq[*Argument] {
+
+	return func(yield func(*Argument) bool) {
+
+		for _, arg := range args.Values {
+
+			if arg.AfterDoubleHyphen {
+
+				if !yield(arg) {
+
+					return
+				}
+			}
+		}
+	}
+}
+
+// Obtains an iterator over all unused flag and option arguments, in
+// command-line order, as obtained by [Arguments.GetUnusedFlagsAndOptions].
+// Arguments are not marked used.
+//
+// Since use is determined as each argument is reached, an argument marked
+// used during iteration before it is reached is not yielded.
+func (args *Arguments) Unused() iter.Seq[*Argument] {
+
+	return func(yield func(*Argument) bool) {
+
+		for _, arg := range args.Arguments {
+
+			switch arg.Type {
+
+			case FlagType, OptionType:
+
+				if arg.isUnused() && !yield(arg) {
+
+					return
+				}
+			}
+		}
+	}
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,120 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"slices"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func iter_arguments() *clasp.Arguments {
+
+	return clasp.Parse([]string{"myprog", "-v", "--include=a", "value1", "-v", "--unknown", "--include=b", "--", "-v", "value2"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--verbose").SetAlias("-v"),
+			clasp.Option("--include"),
+		},
+	})
+}
+
+func str_of(arg *clasp.Argument) string {
+
+	if clasp.ValueType == arg.Type {
+
+		return arg.Value
+	}
+
+	return arg.Str()
+}
+
+func strs_(seq func(yield func(*clasp.Argument) bool)) []string {
+
+	var strs []string
+
+	for arg := range seq {
+
+		strs = append(strs, str_of(arg))
+	}
+
+	return strs
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_Arguments_All(t *testing.T) {
+
+	args := iter_arguments()
+
+	require.Equal(t, []string{"--verbose", "--include=a", "value1", "--verbose", "--unknown", "--include=b", "-v", "value2"}, strs_(args.All()))
+	require.Equal(t, args.Arguments, slices.Collect(args.All()))
+
+	require.Equal(t, 5, len(args.GetUnusedFlagsAndOptions()))
+}
+
+func Test_Arguments_FlagsNamed(t *testing.T) {
+
+	args := iter_arguments()
+
+	require.Equal(t, 2, len(slices.Collect(args.FlagsNamed(clasp.Flag("--verbose")))))
+	require.Equal(t, []string{"--include=a", "--unknown", "--include=b"}, strs_(args.Unused()))
+
+	require.Equal(t, 0, len(slices.Collect(args.FlagsNamed("--other"))))
+
+	require.Panics(t, func() {
+
+		args.FlagsNamed(clasp.Option("--include"))
+	})
+}
+
+func Test_Arguments_OptionsNamed(t *testing.T) {
+
+	args := iter_arguments()
+
+	var values []string
+
+	for arg := range args.OptionsNamed("--include") {
+
+		values = append(values, arg.Value)
+
+		break
+	}
+
+	require.Equal(t, []string{"a"}, values)
+
+	// only the yielded argument is marked used
+
+	require.Equal(t, []string{"--verbose", "--verbose", "--unknown", "--include=b"}, strs_(args.Unused()))
+}
+
+func Test_Arguments_ValuesAfterDoubleHyphen(t *testing.T) {
+
+	args := iter_arguments()
+
+	require.Equal(t, []string{"-v", "value2"}, strs_(args.ValuesAfterDoubleHyphen()))
+
+	args = clasp.Parse([]string{"myprog", "value"}, clasp.ParseParams{})
+
+	require.Nil(t, strs_(args.ValuesAfterDoubleHyphen()))
+}
+
+func Test_Arguments_Unused(t *testing.T) {
+
+	args := iter_arguments()
+
+	require.Equal(t, args.GetUnusedFlagsAndOptions(), slices.Collect(args.Unused()))
+
+	// iteration does not mark arguments used
+
+	require.Equal(t, 5, len(slices.Collect(args.Unused())))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,315 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// The schema of the JSON specifications document read by
+// [LoadSpecifications] and written by [SaveSpecifications] (and by the
+// JSON marshalling of [SpecificationsDocument]) is:
+//
+//	{
+//	  "schema": "clasp-specifications",
+//	  "version": 1,
+//	  "specifications": [
+//	    {
+//	      "type": "flag" | "option" | "section",
+//	      "name": "--verbosity",
+//	      "aliases": [ "-V" ],
+//	      "help": "Specifies the verbosity",
+//	      "values": [ "terse", "chatty" ],
+//	      "bit_flags": 0,
+//	      "bit_flags_64": 0,
+//	      "extras": { "any-key": "any JSON value" },
+//	      "localised_help": { "de": "Legt die Ausführlichkeit fest" },
+//	      "hidden": false,
+//	      "advanced": false,
+//	      "deprecated": { "message": "no longer needed", "replacement": "--level" }
+//	    }
+//	  ]
+//	}
+//
+// Only "type" is required in each specification; all other members are
+// omitted when empty/zero. The members are:
+//
+//   - "type", "name", "aliases", "help", "values", "bit_flags",
+//     "bit_flags_64", and "extras": the corresponding fields of
+//     [Specification];
+//   - "localised_help": the help for each locale (see
+//     [Specification.SetLocalisedHelp]);
+//   - "hidden": see [Specification.SetHidden];
+//   - "advanced": see [Specification.SetAdvanced];
+//   - "deprecated": the message and replacement, either of which may be
+//     omitted, of a deprecated specification (see
+//     [Specification.SetDeprecated]).
+//
+// Members not described here are ignored.
+const (
+	SpecificationsSchemaName    = "clasp-specifications" // The value of the "schema" member of a specifications document.
+	SpecificationsSchemaVersion = 1                      // The value of the "version" member of a specifications document.
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// A JSON specifications document, which is marshalled and unmarshalled in
+// the schema described at [SpecificationsSchemaVersion].
+//
+// NOTE: Flags receiver variables (see [Specification.SetBitFlags]) and
+// completers (see [Specification.SetCompleter]) are not represented.
+type SpecificationsDocument struct {
+	Specifications []Specification // The specifications, each of type [FlagType], [OptionType], or [SectionType].
+}
+
+type specification_json struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name,omitempty"`
+	Aliases    []string               `json:"aliases,omitempty"`
+	Help       string                 `json:"help,omitempty"`
+	ValueSet   []string               `json:"values,omitempty"`
+	BitFlags   int                    `json:"bit_flags,omitempty"`
+	BitFlags64 int64                  `json:"bit_flags_64,omitempty"`
+	Extras     map[string]interface{} `json:"extras,omitempty"`
+
+	LocalisedHelp map[string]string      `json:"localised_help,omitempty"`
+	Hidden        bool                   `json:"hidden,omitempty"`
+	Advanced      bool                   `json:"advanced,omitempty"`
+	Deprecated    *deprecation_info_json `json:"deprecated,omitempty"`
+}
+
+type deprecation_info_json struct {
+	Message     string `json:"message,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+type specifications_document_json struct {
+	Schema         string               `json:"schema"`
+	Version        int                  `json:"version"`
+	Specifications []specification_json `json:"specifications"`
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func arg_type_json_name(at ArgType) (string, error) {
+
+	switch at {
+
+	case FlagType:
+
+		return "flag", nil
+	case OptionType:
+
+		return "option", nil
+	case SectionType:
+
+		return "section", nil
+	default:
+
+		return "", fmt.Errorf("specification type %v cannot be represented in JSON", at)
+	}
+}
+
+func arg_type_from_json_name(name string) (ArgType, error) {
+
+	switch name {
+
+	case "flag":
+
+		return FlagType, nil
+	case "option":
+
+		return OptionType, nil
+	case "section":
+
+		return SectionType, nil
+	default:
+
+		return 0, fmt.Errorf("unrecognised specification type %q", name)
+	}
+}
+
+func specification_to_json(specification Specification) (sj specification_json, err error) {
+
+	if sj.Type, err = arg_type_json_name(specification.Type); err != nil {
+
+		return
+	}
+
+	sj.Name = specification.Name
+	sj.Aliases = specification.Aliases
+	sj.Help = specification.Help
+	sj.ValueSet = specification.ValueSet
+	sj.BitFlags = specification.BitFlags
+	sj.BitFlags64 = specification.BitFlags64
+	sj.Extras = specification.Extras
+
+	sj.LocalisedHelp = specification.localised_help
+	sj.Hidden = specification.hidden
+	sj.Advanced = specification.advanced
+
+	if nil != specification.deprecation {
+
+		sj.Deprecated = &deprecation_info_json{specification.deprecation.message, specification.deprecation.replacement}
+	}
+
+	return
+}
+
+func specification_from_json(sj specification_json) (specification Specification, err error) {
+
+	at, err := arg_type_from_json_name(sj.Type)
+	if err != nil {
+
+		return
+	}
+
+	specification = Specification{
+
+		Type:       at,
+		Name:       sj.Name,
+		Aliases:    sj.Aliases,
+		Help:       sj.Help,
+		ValueSet:   sj.ValueSet,
+		BitFlags:   sj.BitFlags,
+		BitFlags64: sj.BitFlags64,
+		Extras:     sj.Extras,
+
+		localised_help: sj.LocalisedHelp,
+		hidden:         sj.Hidden,
+		advanced:       sj.Advanced,
+	}
+
+	if nil != sj.Deprecated {
+
+		specification.deprecation = &deprecation_info{sj.Deprecated.Message, sj.Deprecated.Replacement}
+	}
+
+	return
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Marshals the document into JSON, in the schema described at
+// [SpecificationsSchemaVersion].
+//
+// An error is returned if any specification is of a type other than
+// [FlagType], [OptionType], or [SectionType], or if any of its extras
+// cannot be marshalled.
+func (document SpecificationsDocument) MarshalJSON() ([]byte, error) {
+
+	dj := specifications_document_json{
+
+		Schema:         SpecificationsSchemaName,
+		Version:        SpecificationsSchemaVersion,
+		Specifications: make([]specification_json, len(document.Specifications)),
+	}
+
+	for i, specification := range document.Specifications {
+
+		sj, err := specification_to_json(specification)
+		if err != nil {
+
+			return nil, fmt.Errorf("specification[%d]: %w", i, err)
+		}
+
+		dj.Specifications[i] = sj
+	}
+
+	return json.Marshal(dj)
+}
+
+// Unmarshals the document from JSON, in the schema described at
+// [SpecificationsSchemaVersion].
+//
+// An error is returned if the JSON is malformed - including if any
+// specification is of a type other than "flag", "option", or "section" -
+// or is not a specifications document of the supported version.
+func (document *SpecificationsDocument) UnmarshalJSON(data []byte) error {
+
+	var dj specifications_document_json
+
+	if err := json.Unmarshal(data, &dj); err != nil {
+
+		return err
+	}
+
+	if SpecificationsSchemaName != dj.Schema {
+
+		return fmt.Errorf("schema %q is not %q", dj.Schema, SpecificationsSchemaName)
+	}
+
+	if SpecificationsSchemaVersion != dj.Version {
+
+		return fmt.Errorf("schema version %d is not supported", dj.Version)
+	}
+
+	specifications := make([]Specification, len(dj.Specifications))
+
+	for i, sj := range dj.Specifications {
+
+		specification, err := specification_from_json(sj)
+		if err != nil {
+
+			return fmt.Errorf("specification[%d]: %w", i, err)
+		}
+
+		specifications[i] = specification
+	}
+
+	document.Specifications = specifications
+
+	return nil
+}
+
+// Loads specifications from a JSON specifications document (see
+// [SpecificationsSchemaVersion] and [SpecificationsDocument]).
+//
+// An error is returned if the document is malformed - including if any
+// specification is of a type other than "flag", "option", or "section" -
+// or is not a specifications document of the supported version.
+func LoadSpecifications(r io.Reader) ([]Specification, error) {
+
+	var document SpecificationsDocument
+
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+
+		return nil, fmt.Errorf("failed to load specifications: %w", err)
+	}
+
+	return document.Specifications, nil
+}
+
+// Saves the given specifications to the given stream as a JSON
+// specifications document (see [SpecificationsSchemaVersion] and
+// [SpecificationsDocument]).
+func SaveSpecifications(specifications []Specification, w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(SpecificationsDocument{Specifications: specifications})
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,133 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_SpecificationsDocument_MarshalJSON(t *testing.T) {
+
+	var flags int
+
+	b, err := json.Marshal(clasp.SpecificationsDocument{Specifications: []clasp.Specification{
+
+		clasp.Section("standard:"),
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "chatty").SetExtra("since", "0.18"),
+		clasp.Flag("--sound").SetBitFlags(0x4, &flags),
+	}})
+
+	require.Nil(t, err)
+	require.Equal(t, `{"schema":"clasp-specifications","version":1,"specifications":[{"type":"section","name":"standard:"},{"type":"option","name":"--verbosity","aliases":["-V"],"help":"Specifies the verbosity","values":["terse","chatty"],"extras":{"since":"0.18"}},{"type":"flag","name":"--sound","bit_flags":4}]}`, string(b))
+}
+
+func Test_SpecificationsDocument_MarshalJSON_invalid(t *testing.T) {
+
+	for _, specification := range []clasp.Specification{
+
+		{},
+		clasp.Flag("--func").SetExtra("f", func() {}),
+		{Type: clasp.ValueType, Name: "x"},
+	} {
+
+		_, err := json.Marshal(clasp.SpecificationsDocument{Specifications: []clasp.Specification{clasp.HelpFlag(), specification}})
+
+		require.NotNil(t, err)
+	}
+}
+
+func Test_SpecificationsDocument_UnmarshalJSON(t *testing.T) {
+
+	var document clasp.SpecificationsDocument
+
+	err := json.Unmarshal([]byte(`{"schema":"clasp-specifications","version":1,"specifications":[{"type":"flag","name":"--sound","aliases":["-s"],"help":"Enables sound","bit_flags_64":8,"extras":{"n":1},"future-member":true}]}`), &document)
+
+	require.Nil(t, err)
+	require.Equal(t, 1, len(document.Specifications))
+
+	specification := document.Specifications[0]
+
+	require.Equal(t, clasp.FlagType, specification.Type)
+	require.Equal(t, "--sound", specification.Name)
+	require.Equal(t, []string{"-s"}, specification.Aliases)
+	require.Equal(t, "Enables sound", specification.Help)
+	require.Equal(t, int64(8), specification.BitFlags64)
+	require.Equal(t, map[string]interface{}{"n": float64(1)}, specification.Extras)
+
+	err = json.Unmarshal([]byte(`{"schema":"clasp-specifications","version":1,"specifications":[{"type":"widget","name":"--sound"}]}`), &document)
+
+	require.NotNil(t, err)
+	require.Equal(t, `specification[0]: unrecognised specification type "widget"`, err.Error())
+}
+
+func Test_Specification_json_Marshal_is_not_customised(t *testing.T) {
+
+	b, err := json.Marshal(clasp.Specification{Type: clasp.ValueType, Name: "x"})
+
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(string(b), `{"Type":`))
+}
+
+func Test_SaveSpecifications_and_LoadSpecifications(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--verbosity").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+		clasp.VersionFlag(),
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.SaveSpecifications(specifications, buf)
+
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), "{\n  \"schema\": \"clasp-specifications\",\n  \"version\": 1,\n"))
+
+	loaded, err := clasp.LoadSpecifications(buf)
+
+	require.Nil(t, err)
+	require.Equal(t, specifications, loaded)
+}
+
+func Test_LoadSpecifications_empty(t *testing.T) {
+
+	loaded, err := clasp.LoadSpecifications(strings.NewReader(`{"schema":"clasp-specifications","version":1}`))
+
+	require.Nil(t, err)
+	require.NotNil(t, loaded)
+	require.Empty(t, loaded)
+}
+
+func Test_LoadSpecifications_invalid(t *testing.T) {
+
+	for _, document := range []string{
+
+		``,
+		`[]`,
+		`{"schema":"something-else","version":1,"specifications":[]}`,
+		`{"schema":"clasp-specifications","specifications":[]}`,
+		`{"schema":"clasp-specifications","version":2,"specifications":[]}`,
+		`{"schema":"clasp-specifications","version":1,"specifications":[{"name":"--untyped"}]}`,
+		`{"schema":"clasp-specifications","version":1,"specifications":[{"type":"value","name":"x"}]}`,
+	} {
+
+		_, err := clasp.LoadSpecifications(strings.NewReader(document))
+
+		require.NotNil(t, err, "document: %s", document)
+		require.True(t, strings.HasPrefix(err.Error(), "failed to load specifications: "))
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	layout_default_width    = 80 // Width used when none is specified and `COLUMNS` is not valid.
+	layout_minimum_width    = 40 // Narrowest width to which usage will be laid out.
+	layout_indent           = 2  // Indent of the names column.
+	layout_gutter           = 2  // Minimum spacing between the names and help columns.
+	layout_max_help_column  = 30 // Rightmost column at which the help column may start.
+	layout_value_set_indent = 2  // Additional indent of the continuation lines of a value set.
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// East Asian Wide and Fullwidth ranges, which occupy two columns.
+var wide_rune_ranges = [][2]rune{
+
+	{0x1100, 0x115F},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x3FFFD},
+}
+
+func rune_width(c rune) int {
+
+	if unicode.In(c, unicode.Mn, unicode.Me, unicode.Cf) {
+
+		return 0
+	}
+
+	for _, r := range wide_rune_ranges {
+
+		if r[0] <= c && c <= r[1] {
+
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// Obtains the number of columns occupied by the given (UTF-8) string when
+// displayed in a terminal.
+func display_width(s string) int {
+
+	w := 0
+
+	for _, c := range s {
+
+		w += rune_width(c)
+	}
+
+	return w
+}
+
+func lookup_env(params UsageParams, key string) (string, bool) {
+
+	if nil != params.LookupEnv {
+
+		return params.LookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
+
+func layout_width(params UsageParams) int {
+
+	width := params.Width
+
+	if 0 == width {
+
+		if s, ok := lookup_env(params, "COLUMNS"); ok {
+
+			if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && n > 0 {
+
+				width = n
+			}
+		}
+	}
+
+	if 0 == width {
+
+		width = layout_default_width
+	}
+
+	if width < layout_minimum_width {
+
+		width = layout_minimum_width
+	}
+
+	return width
+}
+
+// A word to be laid out, whose text may contain (zero-width) escape
+// sequences.
+type layout_word struct {
+	text  string
+	width int
+}
+
+func plain_words(text string) []layout_word {
+
+	fields := strings.Fields(text)
+	words := make([]layout_word, len(fields))
+
+	for i, field := range fields {
+
+		words[i] = layout_word{text: field, width: display_width(field)}
+	}
+
+	return words
+}
+
+// Word-wraps the given text such that, as far as is possible, each line
+// occupies no more than the given number of columns. Words that are wider
+// than the given number of columns are placed, unbroken, on a line of
+// their own.
+func word_wrap(text string, columns int) []string {
+
+	return wrap_words(plain_words(text), columns)
+}
+
+func wrap_words(words []layout_word, columns int) []string {
+
+	var lines []string
+	var line strings.Builder
+
+	line_width := 0
+
+	for _, word := range words {
+
+		word_width := word.width
+
+		if 0 != line_width && line_width+1+word_width > columns {
+
+			lines = append(lines, line.String())
+			line.Reset()
+			line_width = 0
+		}
+
+		if 0 != line_width {
+
+			line.WriteByte(' ')
+			line_width++
+		}
+
+		line.WriteString(word.text)
+		line_width += word_width
+	}
+
+	if 0 != line_width {
+
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+type layout_entry struct {
+	names       string        // The names column, which may contain escape sequences.
+	names_width int           // The display width of the names column.
+	help        []string      // Paragraphs, each wrapped separately.
+	value       []layout_word // The value-set paragraph, which is given a hanging indent.
+}
+
+func two_column_entry(params UsageParams, a Specification, value_aliases []Specification, painter usage_painter) (entry layout_entry) {
+
+	var names []string
+	var plain_names []string
+
+	switch a.Type {
+
+	case FlagType:
+
+		for _, b := range append(append([]string{}, a.Aliases...), a.Name) {
+
+			names = append(names, painter.name(b))
+			plain_names = append(plain_names, b)
+		}
+	case OptionType:
+
+		for _, c := range value_aliases_outside_value_set(a, value_aliases) {
+
+			for _, b := range c.Aliases {
+
+				names = append(names, painter.name(b)+" "+painter.name(c.Name))
+				plain_names = append(plain_names, b+" "+c.Name)
+			}
+		}
+		for _, b := range a.Aliases {
+
+			names = append(names, painter.name(b)+" "+painter.placeholder("<value>"))
+			plain_names = append(plain_names, b+" <value>")
+		}
+		names = append(names, painter.name(a.Name)+"="+painter.placeholder("<value>"))
+		plain_names = append(plain_names, a.Name+"=<value>")
+	}
+
+	entry.names = strings.Join(names, ", ")
+	entry.names_width = display_width(strings.Join(plain_names, ", "))
+
+	if 0 != len(a.Help) {
+
+		entry.help = append(entry.help, a.Help)
+	}
+
+	if 0 != len(a.ValueSet) {
+
+		for _, word := range strings.Fields(usage_message(params, Message_WhereValueOneOf)) {
+
+			entry.value = append(entry.value, layout_word{
+
+				text:  substitute_placeholder(word, painter.placeholder("<value>")),
+				width: display_width(substitute_placeholder(word, "<value>")),
+			})
+		}
+
+		for i, value := range a.ValueSet {
+
+			var aliases []string
+
+			for _, c := range value_aliases {
+
+				if c.Name == a.Name+"="+value {
+
+					aliases = append(aliases, c.Aliases...)
+				}
+			}
+
+			separator := ","
+			if i+1 == len(a.ValueSet) {
+
+				separator = ""
+			}
+
+			if 0 == len(aliases) {
+
+				entry.value = append(entry.value, layout_word{text: painter.value(value) + separator, width: display_width(value + separator)})
+			} else {
+
+				entry.value = append(entry.value, layout_word{text: painter.value(value), width: display_width(value)})
+
+				for j, alias := range aliases {
+
+					prefix, suffix := "", ","
+					if 0 == j {
+
+						prefix = "("
+					}
+					if j+1 == len(aliases) {
+
+						suffix = ")" + separator
+					}
+
+					entry.value = append(entry.value, layout_word{text: prefix + painter.name(alias) + suffix, width: display_width(prefix + alias + suffix)})
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func write_two_column_specifications(params UsageParams, painter usage_painter, specifications []Specification) {
+
+	width := layout_width(params)
+
+	groups, value_aliases := group_specifications(specifications)
+
+	// Determine the help column from the widest names that will fit
+
+	help_column := 0
+	max_help_column := layout_max_help_column
+
+	if max_help_column > width/2 {
+
+		max_help_column = width / 2
+	}
+
+	entries := make([][]layout_entry, len(groups))
+
+	for i, group := range groups {
+
+		for _, a := range group.specifications {
+
+			entry := two_column_entry(params, a, value_aliases[a.Name], painter)
+
+			entries[i] = append(entries[i], entry)
+
+			column := layout_indent + entry.names_width + layout_gutter
+
+			if column <= max_help_column && column > help_column {
+
+				help_column = column
+			}
+		}
+	}
+
+	if 0 == help_column {
+
+		help_column = max_help_column
+	}
+
+	help_width := width - help_column
+	padding := strings.Repeat(" ", help_column)
+
+	for i, group := range groups {
+
+		if "" != group.name {
+
+			if 0 != i {
+
+				fmt.Fprintf(params.Stream, "\n")
+			}
+			fmt.Fprintf(params.Stream, "%v\n", painter.header(group.name))
+		}
+
+		for _, entry := range entries[i] {
+
+			var lines []string
+
+			for _, paragraph := range entry.help {
+
+				lines = append(lines, word_wrap(paragraph, help_width)...)
+			}
+
+			if 0 != len(entry.value) {
+
+				value_lines := wrap_words(entry.value, help_width-layout_value_set_indent)
+
+				for j, value_line := range value_lines {
+
+					if 0 != j {
+
+						value_line = strings.Repeat(" ", layout_value_set_indent) + value_line
+					}
+
+					lines = append(lines, value_line)
+				}
+			}
+
+			names := strings.Repeat(" ", layout_indent) + entry.names
+			names_width := layout_indent + entry.names_width
+
+			if 0 == len(lines) {
+
+				fmt.Fprintf(params.Stream, "%s\n", names)
+			} else if names_width+layout_gutter <= help_column {
+
+				fmt.Fprintf(params.Stream, "%s%s%s\n", names, strings.Repeat(" ", help_column-names_width), lines[0])
+				lines = lines[1:]
+			} else {
+
+				fmt.Fprintf(params.Stream, "%s\n", names)
+			}
+
+			for _, line := range lines {
+
+				fmt.Fprintf(params.Stream, "%s%s\n", padding, line)
+			}
+		}
+	}
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
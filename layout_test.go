@@ -0,0 +1,150 @@
+package clasp_test
+
+import (
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func show_two_column_usage_(t *testing.T, specifications []clasp.Specification, params clasp.UsageParams) string {
+
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	params.Stream = buf
+	params.ProgramName = "myprog"
+	params.UsageFlags |= clasp.DontCallExit | clasp.Usage_TwoColumnLayout
+
+	if _, err := clasp.ShowUsage(specifications, params); err != nil {
+
+		t.Errorf("ShowUsage() failed: %v", err)
+	}
+
+	return buf.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowUsage_TwoColumnLayout_1(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose, which is to say that the program will tell you a great deal more about what it is doing than you probably wanted to know"),
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "quiet", "silent", "chatty", "garrulous", "loquacious"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+		clasp.Flag("--a-very-long-flag-name-indeed").SetHelp("Has a long name"),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+		clasp.VersionFlag(),
+	}
+
+	expected := `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+behaviour:
+  -v, --verbose  Makes output verbose, which is to say that the program will
+                 tell you a great deal more about what it is doing than you
+                 probably wanted to know
+  -V <value>, --verbosity=<value>
+                 Specifies the verbosity
+                 where <value> one of: terse, quiet, silent, chatty (-c),
+                   garrulous, loquacious
+  --a-very-long-flag-name-indeed
+                 Has a long name
+
+standard:
+  --help         Shows this help and exits
+  --version      Shows version information and exits
+`
+
+	stegol.CheckStringEqual(t, expected, show_two_column_usage_(t, specifications, clasp.UsageParams{Width: 78}))
+}
+
+func Test_ShowUsage_TwoColumnLayout_value_aliases_outside_value_set(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Option("--mode").SetAlias("-m").SetHelp("Specifies the mode"),
+		clasp.Flag("--mode=fast").SetAlias("-f"),
+		clasp.Option("--verbosity").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+		clasp.Flag("--verbosity=silent").SetAlias("-s"),
+	}
+
+	expected := `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+  -f --mode=fast, -m <value>, --mode=<value>
+                              Specifies the mode
+  -s --verbosity=silent, --verbosity=<value>
+                              Specifies the verbosity
+                              where <value> one of: terse, chatty (-c)
+`
+
+	stegol.CheckStringEqual(t, expected, show_two_column_usage_(t, specifications, clasp.UsageParams{Width: 78}))
+}
+
+func Test_ShowUsage_TwoColumnLayout_width_from_COLUMNS(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose in a great many ways"),
+	}
+
+	params := clasp.UsageParams{
+
+		UsageFlags: clasp.SkipBlanksBetweenLines,
+		LookupEnv: func(key string) (string, bool) {
+
+			if "COLUMNS" == key {
+
+				return "40", true
+			}
+
+			return "", false
+		},
+	}
+
+	expected := `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+  -v, --verbose  Makes output verbose in
+                 a great many ways
+`
+
+	stegol.CheckStringEqual(t, expected, show_two_column_usage_(t, specifications, params))
+}
+
+func Test_ShowUsage_TwoColumnLayout_multibyte(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--überprüfen").SetAlias("-ü").SetHelp("Prüft die Eingabe gründlich und ausführlich"),
+		clasp.Flag("--表示").SetHelp("詳細 を 表示 する"),
+	}
+
+	expected := `USAGE: myprog [ ... flags and options ... ]
+
+flags/options:
+
+  -ü, --überprüfen  Prüft die Eingabe
+                    gründlich und
+                    ausführlich
+  --表示            詳細 を 表示 する
+`
+
+	stegol.CheckStringEqual(t, expected, show_two_column_usage_(t, specifications, clasp.UsageParams{Width: 40}))
+}
@@ -0,0 +1,299 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Defines options for man page generation (see [GenerateManPage]).
+//
+// The embedded [UsageParams] supplies the program name, version, and the
+// flags/options and values strings of the synopsis, all interpreted as
+// by [ShowUsage]; its stream, exit code, and exiter are ignored.
+type ManParams struct {
+	UsageParams
+	Section     string   // The manual section. If empty, "1" is used.
+	Date        string   // The date shown in the footer. If empty, today's date is used.
+	Summary     string   // The one-line summary shown in the NAME section.
+	Description []string // The paragraphs of the DESCRIPTION section.
+	Authors     []string // The lines of the AUTHORS section, which is omitted if empty.
+	SeeAlso     []string // The references - e.g. "ls(1)" - of the SEE ALSO section, which is omitted if empty.
+}
+
+func (params ManParams) String() string {
+
+	return fmt.Sprintf("<%T{ UsageParams=%v, Section=%q, Date=%q, Summary=%q, Description=%v, Authors=%v, SeeAlso=%v }>", params, params.UsageParams, params.Section, params.Date, params.Summary, params.Description, params.Authors, params.SeeAlso)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Escapes text for roff, such that backslashes and hyphens are rendered
+// literally and no line - including any that follows a newline embedded in
+// the text - can be mistaken for a request.
+func man_escape(s string) string {
+
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	lines := strings.Split(s, "\n")
+
+	for i, line := range lines {
+
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+
+			lines[i] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func man_bold(s string) string {
+
+	return `\fB` + man_escape(s) + `\fR`
+}
+
+func man_italic(s string) string {
+
+	return `\fI` + man_escape(s) + `\fR`
+}
+
+func man_quote(s string) string {
+
+	return `"` + strings.ReplaceAll(man_escape(s), `"`, `\(dq`) + `"`
+}
+
+func man_see_also(reference string) string {
+
+	if ix := strings.Index(reference, "("); ix > 0 && strings.HasSuffix(reference, ")") {
+
+		return man_bold(reference[:ix]) + man_escape(reference[ix:])
+	}
+
+	return man_bold(reference)
+}
+
+func write_man_specification(sb *strings.Builder, a Specification, value_aliases []Specification) {
+
+	var names []string
+
+	switch a.Type {
+
+	case FlagType:
+
+		for _, b := range a.Aliases {
+
+			names = append(names, man_bold(b))
+		}
+		names = append(names, man_bold(a.Name))
+	case OptionType:
+
+		for _, c := range value_aliases_outside_value_set(a, value_aliases) {
+
+			for _, b := range c.Aliases {
+
+				names = append(names, man_bold(b)+" "+man_bold(c.Name))
+			}
+		}
+		for _, b := range a.Aliases {
+
+			names = append(names, man_bold(b)+" "+man_italic("value"))
+		}
+		names = append(names, man_bold(a.Name)+"="+man_italic("value"))
+	}
+
+	fmt.Fprintf(sb, ".TP\n%s\n", strings.Join(names, ", "))
+
+	if 0 != len(a.Help) {
+
+		fmt.Fprintf(sb, "%s\n", man_escape(a.Help))
+	}
+
+	if 0 != len(a.ValueSet) {
+
+		fmt.Fprintf(sb, ".RS\n")
+		for _, value := range a.ValueSet {
+
+			fmt.Fprintf(sb, ".TP\n.B %s\n", man_quote(value))
+
+			for _, c := range value_aliases {
+
+				if c.Name == a.Name+"="+value {
+
+					for _, alias := range c.Aliases {
+
+						fmt.Fprintf(sb, "Also specified as %s.\n", man_bold(alias))
+					}
+					if 0 != len(c.Help) {
+
+						fmt.Fprintf(sb, "%s\n", man_escape(c.Help))
+					}
+				}
+			}
+		}
+		fmt.Fprintf(sb, ".RE\n")
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Writes to the given stream a man page, in roff format, describing the
+// program according to the given specifications and parameters.
+//
+// The page comprises the sections NAME, SYNOPSIS, DESCRIPTION (if any
+// description is given), OPTIONS (if any specifications are given), AUTHORS
+// and SEE ALSO (each only if given). Each [SectionType] specification
+// introduces a subsection of OPTIONS, and the values of an option's
+// [Specification.ValueSet] are rendered as a tagged list.
+func GenerateManPage(specifications []Specification, params ManParams, w io.Writer) error {
+
+	for i := range specifications {
+
+		if err := specification_type_error(specifications, i); err != nil {
+
+			return err
+		}
+	}
+
+	specifications = presented_specifications(specifications, params.UsageParams)
+
+	program_name := get_program_name(params.UsageParams)
+
+	section := params.Section
+	if "" == section {
+
+		section = "1"
+	}
+
+	date := params.Date
+	if "" == date {
+
+		date = time.Now().Format("2006-01-02")
+	}
+
+	source := program_name
+	if nil != params.Version {
+
+		var err error
+
+		if source, err = generate_version_string(params.UsageParams, "GenerateManPage"); err != nil {
+
+			return err
+		}
+	}
+
+	flags_and_options_string := params.FlagsAndOptionsString
+	if "" == flags_and_options_string && 0 != len(specifications) {
+
+		flags_and_options_string = usage_message(params.UsageParams, Message_FlagsAndOptionsPlaceholder)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, ".TH %s %s %s %s\n", man_quote(strings.ToUpper(program_name)), man_quote(section), man_quote(date), man_quote(source))
+
+	fmt.Fprintf(&sb, ".SH NAME\n")
+	if "" != params.Summary {
+
+		fmt.Fprintf(&sb, "%s \\- %s\n", man_escape(program_name), man_escape(params.Summary))
+	} else {
+
+		fmt.Fprintf(&sb, "%s\n", man_escape(program_name))
+	}
+
+	fmt.Fprintf(&sb, ".SH SYNOPSIS\n")
+	fmt.Fprintf(&sb, ".B %s\n", man_quote(program_name))
+	if "" != strings.TrimSpace(flags_and_options_string) {
+
+		fmt.Fprintf(&sb, "%s\n", man_escape(strings.TrimSpace(flags_and_options_string)))
+	}
+	if "" != strings.TrimSpace(params.ValuesString) {
+
+		fmt.Fprintf(&sb, "%s\n", man_escape(strings.TrimSpace(params.ValuesString)))
+	}
+
+	if 0 != len(params.Description) {
+
+		fmt.Fprintf(&sb, ".SH DESCRIPTION\n")
+		for i, paragraph := range params.Description {
+
+			if 0 != i {
+
+				fmt.Fprintf(&sb, ".PP\n")
+			}
+			fmt.Fprintf(&sb, "%s\n", man_escape(paragraph))
+		}
+	}
+
+	groups, value_aliases := group_specifications(specifications)
+
+	if 0 != len(groups) {
+
+		fmt.Fprintf(&sb, ".SH OPTIONS\n")
+
+		for _, group := range groups {
+
+			if "" != group.name {
+
+				fmt.Fprintf(&sb, ".SS %s\n", man_quote(strings.TrimSuffix(group.name, ":")))
+			}
+
+			for _, a := range group.specifications {
+
+				write_man_specification(&sb, a, value_aliases[a.Name])
+			}
+		}
+	}
+
+	if 0 != len(params.Authors) {
+
+		fmt.Fprintf(&sb, ".SH AUTHORS\n")
+		for i, author := range params.Authors {
+
+			if 0 != i {
+
+				fmt.Fprintf(&sb, ".br\n")
+			}
+			fmt.Fprintf(&sb, "%s\n", man_escape(author))
+		}
+	}
+
+	if 0 != len(params.SeeAlso) {
+
+		references := make([]string, len(params.SeeAlso))
+
+		for i, reference := range params.SeeAlso {
+
+			references[i] = man_see_also(reference)
+		}
+
+		fmt.Fprintf(&sb, ".SH \"SEE ALSO\"\n")
+		fmt.Fprintf(&sb, "%s\n", strings.Join(references, ", "))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,167 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_GenerateManPage_no_specifications(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateManPage(nil, clasp.ManParams{
+
+		UsageParams: clasp.UsageParams{
+
+			ProgramName: "myprog",
+		},
+		Date: "19th October 2026",
+	}, buf)
+
+	require.Nil(t, err)
+
+	expected := `.TH "MYPROG" "1" "19th October 2026" "myprog"
+.SH NAME
+myprog
+.SH SYNOPSIS
+.B "myprog"
+`
+
+	stegol.CheckStringEqual(t, expected, buf.String())
+}
+
+func Test_GenerateManPage_full(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--debug").SetHelp(`Writes debug output to C:\temp`),
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := clasp.GenerateManPage(specifications, clasp.ManParams{
+
+		UsageParams: clasp.UsageParams{
+
+			ProgramName:  "myprog",
+			Version:      []int{1, 2, 3},
+			ValuesString: "<path>",
+		},
+		Section:     "8",
+		Date:        "2026-10-19",
+		Summary:     "does things",
+		Description: []string{"First paragraph.", ".Second paragraph."},
+		Authors:     []string{"A. Author", "B. Author"},
+		SeeAlso:     []string{"ls(1)", "CLASP"},
+	}, buf)
+
+	require.Nil(t, err)
+
+	expected := `.TH "MYPROG" "8" "2026\-10\-19" "myprog 1.2.3"
+.SH NAME
+myprog \- does things
+.SH SYNOPSIS
+.B "myprog"
+[ ... flags and options ... ]
+<path>
+.SH DESCRIPTION
+First paragraph.
+.PP
+\&.Second paragraph.
+.SH OPTIONS
+.TP
+\fB\-\-debug\fR
+Writes debug output to C:\etemp
+.SS "behaviour"
+.TP
+\fB\-v\fR, \fB\-\-verbose\fR
+Makes output verbose
+.TP
+\fB\-V\fR \fIvalue\fR, \fB\-\-verbosity\fR=\fIvalue\fR
+Specifies the verbosity
+.RS
+.TP
+.B "terse"
+.TP
+.B "chatty"
+Also specified as \fB\-c\fR.
+.RE
+.SS "standard"
+.TP
+\fB\-\-help\fR
+Shows this help and exits
+.SH AUTHORS
+A. Author
+.br
+B. Author
+.SH "SEE ALSO"
+\fBls\fR(1), \fBCLASP\fR
+`
+
+	stegol.CheckStringEqual(t, expected, buf.String())
+}
+
+func Test_GenerateManPage_rejects_value_specification(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		{Type: clasp.ValueType},
+	}
+
+	err := clasp.GenerateManPage(specifications, clasp.ManParams{}, new(bytes.Buffer))
+
+	require.NotNil(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "specification[0]"))
+	require.Equal(t, `specification[0] (""): is of type Value, but must be of type Flag, Option, or Section`, err.Error())
+
+	var se *clasp.SpecificationError
+
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, 0, se.Index)
+}
+
+func Test_GenerateManPage_escapes_requests_after_newlines(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	err := clasp.GenerateManPage([]clasp.Specification{
+
+		clasp.Flag("--dot").SetHelp(".starts with a dot\n.SH INJECTED\n'also a request\nplain"),
+	}, clasp.ManParams{UsageParams: clasp.UsageParams{ProgramName: "myprog"}, Date: "2026-10-19"}, &buf)
+
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "\\&.starts with a dot\n\\&.SH INJECTED\n\\&'also a request\nplain\n")
+	require.NotContains(t, buf.String(), "\n.SH INJECTED")
+}
+
+func Test_GenerateManPage_value_aliases_outside_value_set(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	err := clasp.GenerateManPage([]clasp.Specification{
+
+		clasp.Option("--mode").SetAlias("-m").SetHelp("Specifies the mode"),
+		clasp.Flag("--mode=fast").SetAlias("-f"),
+	}, clasp.ManParams{UsageParams: clasp.UsageParams{ProgramName: "myprog"}, Date: "2026-10-19"}, &buf)
+
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), ".TP\n\\fB\\-f\\fR \\fB\\-\\-mode=fast\\fR, \\fB\\-m\\fR \\fIvalue\\fR, \\fB\\-\\-mode\\fR=\\fIvalue\\fR\nSpecifies the mode\n")
+}
@@ -0,0 +1,391 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"maps"
+	"os"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Protocol for a catalogue of the (localised) text of built-in messages,
+// each identified by one of the `Message_` keys.
+type MessageCatalogue interface {
+	// Obtains the message for the given key, or `false` if the catalogue
+	// does not contain it, in which case a built-in message is used.
+	Message(key string) (string, bool)
+}
+
+// A [MessageCatalogue] implemented as a map of keys to messages.
+type MapMessageCatalogue map[string]string
+
+func (catalogue MapMessageCatalogue) Message(key string) (string, bool) {
+
+	message, ok := catalogue[key]
+
+	return message, ok
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// Message keys.
+const (
+	Message_Usage                      = "usage"                         // The usage heading, e.g. "USAGE:".
+	Message_FlagsAndOptions            = "flags-and-options"             // The flags/options heading, e.g. "flags/options:".
+	Message_FlagsAndOptionsPlaceholder = "flags-and-options-placeholder" // The default flags/options string of the usage line, e.g. "[ ... flags and options ... ]".
+	Message_WhereValueOneOf            = "where-value-one-of"            // The introduction to a value set, in which "%s" is replaced by the placeholder "<value>", e.g. "where %s one of:".
+	Message_HelpFlagHelp               = "help-flag-help"                // The help of the stock help flag (see [HelpFlag]).
+	Message_VersionFlagHelp            = "version-flag-help"             // The help of the stock version flag (see [VersionFlag]).
+	Message_UnrecognisedFlagOrOption   = "unrecognised-flag-or-option"   // The report of an unrecognised flag/option, in which the first "%s" is replaced by the program name and the second by the argument, e.g. "%s: unrecognised flag/option: %s".
+	Message_DidYouMean                 = "did-you-mean"                  // The hint of suggestions for a mistyped argument, in which "%s" is replaced by the suggestions, e.g. "did you mean %s?".
+	Message_UseHelpForUsage            = "use-help-for-usage"            // The hint to obtain usage, in which the first "%s" is replaced by the program name and the second by the help flag, e.g. "%s: use %s for usage".
+	Message_UnexpectedValue            = "unexpected-value"              // The report of an unexpected value, in which the first "%s" is replaced by the program name and the second by the value, e.g. "%s: unexpected value: %s".
+	Message_DeprecatedWarning          = "deprecated-warning"            // The warning of the use of a deprecated argument, in which the first "%s" is replaced by the program name and the second by the argument, e.g. "%s: warning: %s is deprecated".
+	Message_DeprecatedNote             = "deprecated-note"               // The annotation of the help of a deprecated flag/option, e.g. "deprecated".
+	Message_UseInstead                 = "use-instead"                   // The advice to use a replacement for a deprecated argument, in which "%s" is replaced by the replacement, e.g. "use %s instead".
+	Message_HelpAllFlagHelp            = "help-all-flag-help"            // The help of the stock help-all flag (see [HelpAllFlag]).
+	Message_NoHelpTopic                = "no-help-topic"                 // The report of a help topic that matches no section or flag/option, in which the first "%s" is replaced by the program name and the second by the topic, e.g. "%s: no help topic: %s".
+	Message_LicenceLabel               = "licence-label"                 // The label of the licence metadata line (see [ApplicationMetadata]), e.g. "Licence".
+	Message_AuthorsLabel               = "authors-label"                 // The label of the authors metadata line, e.g. "Authors".
+	Message_BuildDateLabel             = "build-date-label"              // The label of the build date metadata line, e.g. "Build date".
+	Message_GoVersionLabel             = "go-version-label"              // The label of the Go version metadata line, e.g. "Go version".
+	Message_CommitLabel                = "commit-label"                  // The label of the commit metadata line, e.g. "Commit".
+	Message_CommitDateLabel            = "commit-date-label"             // The label of the commit date metadata line, e.g. "Commit date".
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * locals
+ */
+
+var builtin_message_catalogues = map[string]MapMessageCatalogue{
+
+	"en": {
+
+		Message_Usage:                      "USAGE:",
+		Message_FlagsAndOptions:            "flags/options:",
+		Message_FlagsAndOptionsPlaceholder: "[ ... flags and options ... ]",
+		Message_WhereValueOneOf:            "where %s one of:",
+		Message_HelpFlagHelp:               "Shows this help and exits",
+		Message_VersionFlagHelp:            "Shows version information and exits",
+		Message_UnrecognisedFlagOrOption:   "%s: unrecognised flag/option: %s",
+		Message_DidYouMean:                 "did you mean %s?",
+		Message_UseHelpForUsage:            "%s: use %s for usage",
+		Message_UnexpectedValue:            "%s: unexpected value: %s",
+		Message_DeprecatedWarning:          "%s: warning: %s is deprecated",
+		Message_DeprecatedNote:             "deprecated",
+		Message_UseInstead:                 "use %s instead",
+		Message_HelpAllFlagHelp:            "Shows help for all flags and options, including advanced ones, and exits",
+		Message_NoHelpTopic:                "%s: no help topic: %s",
+		Message_LicenceLabel:               "Licence",
+		Message_AuthorsLabel:               "Authors",
+		Message_BuildDateLabel:             "Build date",
+		Message_GoVersionLabel:             "Go version",
+		Message_CommitLabel:                "Commit",
+		Message_CommitDateLabel:            "Commit date",
+	},
+	"de": {
+
+		Message_Usage:                      "AUFRUF:",
+		Message_FlagsAndOptions:            "Schalter/Optionen:",
+		Message_FlagsAndOptionsPlaceholder: "[ ... Schalter und Optionen ... ]",
+		Message_WhereValueOneOf:            "wobei %s eines von:",
+		Message_HelpFlagHelp:               "Zeigt diese Hilfe an und beendet das Programm",
+		Message_VersionFlagHelp:            "Zeigt Versionsinformationen an und beendet das Programm",
+		Message_UnrecognisedFlagOrOption:   "%s: unbekannter Schalter bzw. unbekannte Option: %s",
+		Message_DidYouMean:                 "meinten Sie %s?",
+		Message_UseHelpForUsage:            "%s: verwenden Sie %s, um die Hilfe anzuzeigen",
+		Message_UnexpectedValue:            "%s: unerwarteter Wert: %s",
+		Message_DeprecatedWarning:          "%s: Warnung: %s ist veraltet",
+		Message_DeprecatedNote:             "veraltet",
+		Message_UseInstead:                 "verwenden Sie stattdessen %s",
+		Message_HelpAllFlagHelp:            "Zeigt die Hilfe zu allen Schaltern und Optionen, einschließlich der erweiterten, an und beendet das Programm",
+		Message_NoHelpTopic:                "%s: kein Hilfethema: %s",
+		Message_LicenceLabel:               "Lizenz",
+		Message_AuthorsLabel:               "Autoren",
+		Message_BuildDateLabel:             "Erstellungsdatum",
+		Message_GoVersionLabel:             "Go-Version",
+		Message_CommitLabel:                "Commit",
+		Message_CommitDateLabel:            "Commit-Datum",
+	},
+	"ja": {
+
+		Message_Usage:                      "使い方:",
+		Message_FlagsAndOptions:            "フラグ/オプション:",
+		Message_FlagsAndOptionsPlaceholder: "[ ... フラグとオプション ... ]",
+		Message_WhereValueOneOf:            "%s は次のいずれか:",
+		Message_HelpFlagHelp:               "このヘルプを表示して終了します",
+		Message_VersionFlagHelp:            "バージョン情報を表示して終了します",
+		Message_UnrecognisedFlagOrOption:   "%s: 認識できないフラグ/オプション: %s",
+		Message_DidYouMean:                 "もしかして: %s",
+		Message_UseHelpForUsage:            "%s: 使い方は %s で表示できます",
+		Message_UnexpectedValue:            "%s: 予期しない値: %s",
+		Message_DeprecatedWarning:          "%s: 警告: %s は非推奨です",
+		Message_DeprecatedNote:             "非推奨",
+		Message_UseInstead:                 "代わりに %s を使用してください",
+		Message_HelpAllFlagHelp:            "詳細なものを含むすべてのフラグとオプションのヘルプを表示して終了します",
+		Message_NoHelpTopic:                "%s: ヘルプトピックがありません: %s",
+		Message_LicenceLabel:               "ライセンス",
+		Message_AuthorsLabel:               "作者",
+		Message_BuildDateLabel:             "ビルド日",
+		Message_GoVersionLabel:             "Go バージョン",
+		Message_CommitLabel:                "コミット",
+		Message_CommitDateLabel:            "コミット日時",
+	},
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func locale_language(locale string) string {
+
+	if ix := strings.IndexAny(locale, "_-"); ix >= 0 {
+
+		return locale[:ix]
+	}
+
+	return locale
+}
+
+// Obtains the message for the given key from the given catalogue, if
+// any, or else from the built-in catalogue for the given locale, or else
+// in English.
+func lookup_message(catalogue MessageCatalogue, locale string, key string) string {
+
+	if nil != catalogue {
+
+		if message, ok := catalogue.Message(key); ok {
+
+			return message
+		}
+	}
+
+	if builtin := builtin_message_catalogue(locale); nil != builtin {
+
+		if message, ok := builtin.Message(key); ok {
+
+			return message
+		}
+	}
+
+	return builtin_message_catalogues["en"][key]
+}
+
+func usage_message(params UsageParams, key string) string {
+
+	return lookup_message(params.Messages, params.Locale, key)
+}
+
+// Obtains the built-in message catalogue for the given locale - or, if
+// there is none, for its language - or `nil` if there is none.
+func builtin_message_catalogue(locale string) MapMessageCatalogue {
+
+	if catalogue, ok := builtin_message_catalogues[locale]; ok {
+
+		return catalogue
+	}
+
+	if catalogue, ok := builtin_message_catalogues[locale_language(locale)]; ok {
+
+		return catalogue
+	}
+
+	return nil
+}
+
+// Obtains the given message with each "%s" replaced by the given
+// placeholder, e.g. "where <value> one of:" from "where %s one of:".
+// Unlike formatting, this tolerates messages - in particular, those of a
+// custom catalogue - that contain no "%s", or that contain other '%'
+// characters.
+func substitute_placeholder(message string, placeholder string) string {
+
+	return strings.ReplaceAll(message, "%s", placeholder)
+}
+
+// Obtains the given message with the first "%s" replaced by the first of
+// the given arguments, the second by the second, and so on, e.g.
+// "myprog: unexpected value: x" from "%s: unexpected value: %s". As with
+// substitute_placeholder(), this tolerates messages that contain fewer
+// (or more) "%s" than arguments, or that contain other '%' characters:
+// unused arguments are ignored, and any other "%s" are left as is.
+func substitute_arguments(message string, arguments ...string) string {
+
+	var sb strings.Builder
+
+	for _, argument := range arguments {
+
+		ix := strings.Index(message, "%s")
+		if ix < 0 {
+
+			break
+		}
+
+		sb.WriteString(message[:ix])
+		sb.WriteString(argument)
+
+		message = message[ix+2:]
+	}
+
+	sb.WriteString(message)
+
+	return sb.String()
+}
+
+// Obtains the help of the given specification, localised for the given
+// locale: a localised help (see [Specification.SetLocalisedHelp]) for the
+// locale or, failing that, its language; or else, for an unmodified stock
+// help or version flag, the corresponding message; or else the help.
+func localised_help(specification Specification, catalogue MessageCatalogue, locale string) string {
+
+	if "" != locale {
+
+		if help, ok := specification.localised_help[locale]; ok {
+
+			return help
+		}
+
+		if help, ok := specification.localised_help[locale_language(locale)]; ok {
+
+			return help
+		}
+	}
+
+	if FlagType == specification.Type {
+
+		if help_flag := HelpFlag(); help_flag.Name == specification.Name && help_flag.Help == specification.Help {
+
+			return lookup_message(catalogue, locale, Message_HelpFlagHelp)
+		}
+
+		if version_flag := VersionFlag(); version_flag.Name == specification.Name && version_flag.Help == specification.Help {
+
+			return lookup_message(catalogue, locale, Message_VersionFlagHelp)
+		}
+
+		if help_all_flag := HelpAllFlag(); help_all_flag.Name == specification.Name && help_all_flag.Help == specification.Help {
+
+			return lookup_message(catalogue, locale, Message_HelpAllFlagHelp)
+		}
+	}
+
+	return specification.Help
+}
+
+// Obtains a copy of the given specifications with each help localised
+// (see [localised_help]).
+func localise_specifications(specifications []Specification, params UsageParams) []Specification {
+
+	if nil == params.Messages && "" == params.Locale {
+
+		return specifications
+	}
+
+	localised := make([]Specification, len(specifications))
+
+	for i, specification := range specifications {
+
+		specification.Help = localised_help(specification, params.Messages, params.Locale)
+
+		localised[i] = specification
+	}
+
+	return localised
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Builder method to set the help string for a specification in the given
+// locale - e.g. "de", or "de_CH" - which is used in preference to
+// [Specification.Help] when usage is shown in that locale (see
+// [UsageParams.Locale]). The help for a locale that specifies a territory
+// is used in preference to that for its language.
+func (specification Specification) SetLocalisedHelp(locale string, help string) Specification {
+
+	localised := make(map[string]string, len(specification.localised_help)+1)
+
+	for k, v := range specification.localised_help {
+
+		localised[k] = v
+	}
+
+	localised[locale] = help
+
+	specification.localised_help = localised
+
+	return specification
+}
+
+// Obtains the locale for messages from the environment variables
+// `LC_ALL`, `LC_MESSAGES`, and `LANG`, in that order of precedence, in the
+// form `<language>[_<territory>]`, e.g. "de_DE", with any codeset and
+// modifier removed. The "C" and "POSIX" locales are returned as the empty
+// string.
+//
+// If lookupEnv is `nil`, [os.LookupEnv] is used.
+func LocaleFromEnvironment(lookupEnv func(key string) (string, bool)) string {
+
+	if nil == lookupEnv {
+
+		lookupEnv = os.LookupEnv
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+
+		if value, ok := lookupEnv(key); ok && "" != value {
+
+			if ix := strings.IndexAny(value, ".@"); ix >= 0 {
+
+				value = value[:ix]
+			}
+
+			switch value {
+
+			case "C", "POSIX":
+
+				return ""
+			default:
+
+				return value
+			}
+		}
+	}
+
+	return ""
+}
+
+// Obtains a copy of the built-in message catalogue for the given locale -
+// or, if there is none, for its language - or `nil` if there is none.
+// Built-in catalogues are provided for English ("en"), German ("de"), and
+// Japanese ("ja").
+//
+// Since the catalogue obtained is a copy, it may be modified - e.g. to
+// serve as the basis of a custom catalogue (see [UsageParams.Messages]) -
+// without affecting the built-in messages.
+func BuiltInMessageCatalogue(locale string) MessageCatalogue {
+
+	if catalogue := builtin_message_catalogue(locale); nil != catalogue {
+
+		return maps.Clone(catalogue)
+	}
+
+	return nil
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
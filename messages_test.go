@@ -0,0 +1,270 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func localised_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetLocalisedHelp("de", "Legt die Ausführlichkeit fest").SetLocalisedHelp("ja", "詳細度を指定します").SetValues("terse", "chatty"),
+
+		clasp.HelpFlag(),
+	}
+}
+
+func show_localised_usage_(t *testing.T, params clasp.UsageParams) string {
+
+	t.Helper()
+
+	stream := new(bytes.Buffer)
+
+	params.Stream = stream
+	params.ProgramName = "myprog"
+	params.UsageFlags |= clasp.DontCallExit | clasp.SkipBlanksBetweenLines
+
+	_, err := clasp.ShowUsage(localised_specifications(), params)
+
+	require.Nil(t, err)
+
+	return stream.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowUsage_German(t *testing.T) {
+
+	expected := "AUFRUF: myprog [ ... Schalter und Optionen ... ]\n" +
+		"\n" +
+		"Schalter/Optionen:\n" +
+		"\t-V <value>\n" +
+		"\t--verbosity=<value>\n" +
+		"\t\tLegt die Ausführlichkeit fest\n" +
+		"\t\twobei <value> eines von:\n" +
+		"\t\t\tterse\n" +
+		"\t\t\tchatty\n" +
+		"\t--help\n" +
+		"\t\tZeigt diese Hilfe an und beendet das Programm\n"
+
+	stegol.CheckStringEqual(t, expected, show_localised_usage_(t, clasp.UsageParams{Locale: "de_DE"}))
+}
+
+func Test_ShowUsage_Japanese(t *testing.T) {
+
+	expected := "使い方: myprog [ ... フラグとオプション ... ]\n" +
+		"\n" +
+		"フラグ/オプション:\n" +
+		"\t-V <value>\n" +
+		"\t--verbosity=<value>\n" +
+		"\t\t詳細度を指定します\n" +
+		"\t\t<value> は次のいずれか:\n" +
+		"\t\t\tterse\n" +
+		"\t\t\tchatty\n" +
+		"\t--help\n" +
+		"\t\tこのヘルプを表示して終了します\n"
+
+	stegol.CheckStringEqual(t, expected, show_localised_usage_(t, clasp.UsageParams{Locale: "ja_JP"}))
+}
+
+func Test_ShowUsage_unsupported_locale_falls_back_to_English(t *testing.T) {
+
+	expected := "USAGE: myprog [ ... flags and options ... ]\n" +
+		"\n" +
+		"flags/options:\n" +
+		"\t-V <value>\n" +
+		"\t--verbosity=<value>\n" +
+		"\t\tSpecifies the verbosity\n" +
+		"\t\twhere <value> one of:\n" +
+		"\t\t\tterse\n" +
+		"\t\t\tchatty\n" +
+		"\t--help\n" +
+		"\t\tShows this help and exits\n"
+
+	stegol.CheckStringEqual(t, expected, show_localised_usage_(t, clasp.UsageParams{Locale: "fr_FR"}))
+}
+
+func Test_ShowUsage_custom_MessageCatalogue(t *testing.T) {
+
+	catalogue := clasp.MapMessageCatalogue{
+
+		clasp.Message_Usage:        "Verwendung:",
+		clasp.Message_HelpFlagHelp: "Hilfe",
+	}
+
+	actual := show_localised_usage_(t, clasp.UsageParams{Locale: "de", Messages: catalogue})
+
+	require.Contains(t, actual, "Verwendung: myprog [ ... Schalter und Optionen ... ]\n")
+	require.Contains(t, actual, "\t--help\n\t\tHilfe\n")
+}
+
+func Test_ShowUsage_custom_MessageCatalogue_without_placeholder(t *testing.T) {
+
+	catalogue := clasp.MapMessageCatalogue{
+
+		clasp.Message_WhereValueOneOf: "where <value> is one of (100%):",
+	}
+
+	for _, flags := range []clasp.UsageFlag{0, clasp.Usage_TwoColumnLayout} {
+
+		actual := show_localised_usage_(t, clasp.UsageParams{Messages: catalogue, UsageFlags: flags, Width: 200})
+
+		require.Contains(t, actual, "where <value> is one of (100%):")
+		require.NotContains(t, actual, "%!")
+	}
+}
+
+func Test_ShowUsage_modified_stock_flag_is_not_translated(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowUsage([]clasp.Specification{
+
+		clasp.HelpFlag().SetHelp("Shows the help"),
+	}, clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines,
+		Locale:      "de",
+	})
+
+	require.Nil(t, err)
+
+	require.Contains(t, stream.String(), "\t--help\n\t\tShows the help\n")
+}
+
+func Test_SetLocalisedHelp_territory_preferred_to_language(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowUsage([]clasp.Specification{
+
+		clasp.Flag("--colour").SetHelp("Colours output").SetLocalisedHelp("en_US", "Colors output").SetLocalisedHelp("en", "Colours the output"),
+	}, clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit | clasp.SkipBlanksBetweenLines,
+		Locale:      "en_US",
+	})
+
+	require.Nil(t, err)
+
+	require.Contains(t, stream.String(), "\t--colour\n\t\tColors output\n")
+}
+
+func Test_LocaleFromEnvironment(t *testing.T) {
+
+	require.Equal(t, "", clasp.LocaleFromEnvironment(fake_env(nil)))
+	require.Equal(t, "de_DE", clasp.LocaleFromEnvironment(fake_env(map[string]string{"LANG": "de_DE.UTF-8"})))
+	require.Equal(t, "ja_JP", clasp.LocaleFromEnvironment(fake_env(map[string]string{"LANG": "de_DE.UTF-8", "LC_MESSAGES": "ja_JP.UTF-8"})))
+	require.Equal(t, "en_GB", clasp.LocaleFromEnvironment(fake_env(map[string]string{"LC_ALL": "en_GB@euro", "LC_MESSAGES": "ja_JP.UTF-8"})))
+	require.Equal(t, "", clasp.LocaleFromEnvironment(fake_env(map[string]string{"LC_ALL": "C.UTF-8", "LANG": "de_DE"})))
+	require.Equal(t, "de", clasp.LocaleFromEnvironment(fake_env(map[string]string{"LC_MESSAGES": "", "LANG": "de"})))
+}
+
+func Test_BuiltInMessageCatalogue(t *testing.T) {
+
+	require.Nil(t, clasp.BuiltInMessageCatalogue("fr"))
+
+	message, ok := clasp.BuiltInMessageCatalogue("de_AT").Message(clasp.Message_FlagsAndOptions)
+
+	require.True(t, ok)
+	require.Equal(t, "Schalter/Optionen:", message)
+}
+
+func Test_BuiltInMessageCatalogue_is_a_copy(t *testing.T) {
+
+	catalogue := clasp.BuiltInMessageCatalogue("en").(clasp.MapMessageCatalogue)
+
+	catalogue[clasp.Message_Usage] = "HIJACKED:"
+
+	message, _ := clasp.BuiltInMessageCatalogue("en").Message(clasp.Message_Usage)
+
+	require.Equal(t, "USAGE:", message)
+	require.Contains(t, show_localised_usage_(t, clasp.UsageParams{}), "USAGE: myprog")
+}
+
+func Test_UnusedFlagsAndOptionsError(t *testing.T) {
+
+	argv := []string{"myprog", "--verbose", "-x", "value"}
+
+	args := clasp.Parse(argv, clasp.ParseParams{Locale: "de_DE"})
+
+	require.True(t, args.FlagIsSpecified(clasp.Flag("--verbose")))
+
+	err := args.UnusedFlagsAndOptionsError()
+
+	require.NotNil(t, err)
+	require.Equal(t, "myprog: unbekannter Schalter bzw. unbekannte Option: -x", err.Error())
+
+	var uae *clasp.UnrecognisedArgumentError
+
+	require.True(t, errors.As(err, &uae))
+	require.Equal(t, "myprog", uae.ProgramName)
+	require.Equal(t, "-x", uae.Argument.ResolvedName)
+}
+
+func Test_UnusedFlagsAndOptionsError_none(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "value"}, clasp.ParseParams{})
+
+	require.Nil(t, args.UnusedFlagsAndOptionsError())
+}
+
+func Test_UnusedFlagsAndOptionsError_custom_MessageCatalogue(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "--a", "--b=c"}, clasp.ParseParams{
+
+		Messages: clasp.MapMessageCatalogue{clasp.Message_UnrecognisedFlagOrOption: "%s: what is %s?"},
+	})
+
+	require.Equal(t, "myprog: what is --a?\nmyprog: what is --b=c?", args.UnusedFlagsAndOptionsError().Error())
+}
+
+func Test_custom_MessageCatalogue_without_placeholders(t *testing.T) {
+
+	messages := clasp.MapMessageCatalogue{
+
+		clasp.Message_UnrecognisedFlagOrOption: "100% unrecognised",
+		clasp.Message_DidYouMean:               "check the spelling",
+		clasp.Message_UnexpectedValue:          "%s: too many values",
+		clasp.Message_DeprecatedWarning:        "deprecated!",
+		clasp.Message_UseInstead:               "use something else",
+	}
+
+	args := clasp.Parse([]string{"myprog", "--verbos", "value"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{clasp.Flag("--verbose")},
+		Messages:       messages,
+	})
+
+	require.Equal(t, "100% unrecognised; check the spelling", args.UnusedFlagsAndOptionsError().Error())
+	require.Equal(t, "myprog: too many values", args.UnusedValuesError().Error())
+
+	var warnings []string
+
+	clasp.Parse([]string{"myprog", "--old"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{clasp.Flag("--old").SetDeprecated("", "--new")},
+		Messages:       messages,
+		WarningSink:    clasp.WarningSinkFunc(func(message string) { warnings = append(warnings, message) }),
+	})
+
+	require.Equal(t, []string{"deprecated!; use something else"}, warnings)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,295 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Metadata describing an application, shown by [ShowVersion] in the long
+// and JSON formats (see [VersionFormat]) and available to the info lines
+// of [ShowUsage] (see [UsageParams.Metadata]).
+type ApplicationMetadata struct {
+	Copyright  string   // The copyright statement, e.g. "Copyright (c) 2026 Synesis Information Systems".
+	Licence    string   // The licence, e.g. "BSD-3-Clause".
+	Authors    []string // The authors.
+	BuildDate  string   // The build date, e.g. "2026-10-19T09:30:00Z", which is not recorded in the build information (see [ApplicationMetadataFromBuildInfo]), so must be specified explicitly, e.g. via `-ldflags "-X main.buildDate=..."`.
+	GoVersion  string   // The Go version with which the program was built, e.g. "go1.23.6".
+	Commit     string   // The VCS commit from which the program was built.
+	CommitDate string   // The time of the VCS commit from which the program was built, e.g. "2026-10-19T09:30:00Z".
+}
+
+func (metadata ApplicationMetadata) String() string {
+
+	return fmt.Sprintf("<%T{ Copyright=%q, Licence=%q, Authors=%v, BuildDate=%q, GoVersion=%q, Commit=%q, CommitDate=%q }>", metadata, metadata.Copyright, metadata.Licence, metadata.Authors, metadata.BuildDate, metadata.GoVersion, metadata.Commit, metadata.CommitDate)
+}
+
+// The format in which [ShowVersion] shows the version.
+type VersionFormat int
+
+type version_json struct {
+	Program    string   `json:"program"`
+	Version    string   `json:"version"`
+	Copyright  string   `json:"copyright,omitempty"`
+	Licence    string   `json:"licence,omitempty"`
+	Authors    []string `json:"authors,omitempty"`
+	BuildDate  string   `json:"build_date,omitempty"`
+	GoVersion  string   `json:"go_version,omitempty"`
+	Commit     string   `json:"commit,omitempty"`
+	CommitDate string   `json:"commit_date,omitempty"`
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+const (
+	VersionFormat_Short VersionFormat = iota // The program name and (prefixed) version, e.g. "myprog v1.2.3".
+	VersionFormat_Long                       // The short form followed by a line for each metadata item specified (see [UsageParams.Metadata]).
+	VersionFormat_JSON                       // A single-line JSON object with the members "program" and "version" (without prefix), and "copyright", "licence", "authors", "build_date", "go_version", "commit", and "commit_date" for each metadata item specified.
+)
+
+func (format VersionFormat) String() string {
+
+	switch format {
+
+	case VersionFormat_Short:
+
+		return "short"
+	case VersionFormat_Long:
+
+		return "long"
+	case VersionFormat_JSON:
+
+		return "json"
+	default:
+
+		return fmt.Sprintf("VersionFormat(%d)", int(format))
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+var metadata_info_line_tokens = []string{
+
+	":copyright:",
+	":licence:",
+	":authors:",
+	":build-date:",
+	":go-version:",
+	":commit:",
+	":commit-date:",
+}
+
+// Obtains the line for the given metadata info-line token, which is the
+// empty string if the corresponding metadata item (see
+// [UsageParams.Metadata]) is not specified, and whether the line is a
+// metadata token. Each label is localised (see [UsageParams.Locale]).
+func metadata_line(params UsageParams, token string) (line string, is_token bool) {
+
+	var m ApplicationMetadata
+
+	if nil != params.Metadata {
+
+		m = *params.Metadata
+	}
+
+	label_line := func(key string, value string) string {
+
+		if "" == value {
+
+			return ""
+		}
+
+		return usage_message(params, key) + ": " + value
+	}
+
+	switch token {
+
+	case ":copyright:":
+
+		return m.Copyright, true
+	case ":licence:":
+
+		return label_line(Message_LicenceLabel, m.Licence), true
+	case ":authors:":
+
+		return label_line(Message_AuthorsLabel, strings.Join(m.Authors, ", ")), true
+	case ":build-date:":
+
+		return label_line(Message_BuildDateLabel, m.BuildDate), true
+	case ":go-version:":
+
+		return label_line(Message_GoVersionLabel, m.GoVersion), true
+	case ":commit:":
+
+		return label_line(Message_CommitLabel, m.Commit), true
+	case ":commit-date:":
+
+		return label_line(Message_CommitDateLabel, m.CommitDate), true
+	default:
+
+		return "", false
+	}
+}
+
+// Obtains the info lines with each ":version:" line replaced by the
+// version string and each metadata token replaced by the corresponding
+// metadata line, or removed if that is empty.
+func expand_info_lines(params UsageParams, apiFunctionName string) (info_lines []string, err error) {
+
+	for _, info_line := range params.InfoLines {
+
+		if ":version:" == info_line {
+
+			if info_line, err = generate_version_string(params, apiFunctionName); err != nil {
+
+				return nil, err
+			}
+		} else if line, is_token := metadata_line(params, info_line); is_token {
+
+			if "" == line {
+
+				continue
+			}
+
+			info_line = line
+		}
+
+		info_lines = append(info_lines, info_line)
+	}
+
+	return
+}
+
+func version_json_string(program_name string, version string, metadata *ApplicationMetadata) (string, error) {
+
+	vj := version_json{
+
+		Program: program_name,
+		Version: version,
+	}
+
+	if nil != metadata {
+
+		vj.Copyright = metadata.Copyright
+		vj.Licence = metadata.Licence
+		vj.Authors = metadata.Authors
+		vj.BuildDate = metadata.BuildDate
+		vj.GoVersion = metadata.GoVersion
+		vj.Commit = metadata.Commit
+		vj.CommitDate = metadata.CommitDate
+	}
+
+	b, err := json.Marshal(vj)
+	if err != nil {
+
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains the version format named by the given string - "short",
+// "long", or "json" (in any case) - or an error if it names no format.
+func ParseVersionFormat(s string) (VersionFormat, error) {
+
+	for _, format := range []VersionFormat{VersionFormat_Short, VersionFormat_Long, VersionFormat_JSON} {
+
+		if strings.EqualFold(s, format.String()) {
+
+			return format, nil
+		}
+	}
+
+	return VersionFormat_Short, fmt.Errorf("unrecognised version format %q: must be one of short, long, json", s)
+}
+
+// Looks for the stock version flag (see [VersionFlag]) - "--version" - or
+// an option of the same name - e.g. "--version=json" - in the parsed
+// arguments, for use as [UsageParams.VersionFormat].
+//
+// If the flag is found, the format [VersionFormat_Short] is obtained; if
+// the option is found, the format named by its value (see
+// [ParseVersionFormat]) is obtained, or an error if the value names no
+// format. In either case, the argument is marked used.
+func (args *Arguments) LookupVersionFormat() (format VersionFormat, found bool, err error) {
+
+	name := VersionFlag().Name
+
+	if args.FlagIsSpecified(name) {
+
+		return VersionFormat_Short, true, nil
+	}
+
+	if arg, ok := args.LookupOption(name); ok {
+
+		format, err = ParseVersionFormat(arg.Value)
+
+		return format, true, err
+	}
+
+	return VersionFormat_Short, false, nil
+}
+
+// Obtains application metadata comprising the Go version, VCS commit, and
+// VCS commit time (but not the build date, which is not recorded in the
+// build information) from the build information of the program (see
+// [debug.ReadBuildInfo]), to which may be added the copyright, licence,
+// authors, and build date.
+//
+// If readBuildInfo is `nil`, [debug.ReadBuildInfo] is used. If the build
+// information is not available, empty metadata is returned.
+func ApplicationMetadataFromBuildInfo(readBuildInfo func() (*debug.BuildInfo, bool)) (metadata ApplicationMetadata) {
+
+	if nil == readBuildInfo {
+
+		readBuildInfo = debug.ReadBuildInfo
+	}
+
+	info, ok := readBuildInfo()
+	if !ok || nil == info {
+
+		return
+	}
+
+	metadata.GoVersion = info.GoVersion
+
+	for _, setting := range info.Settings {
+
+		switch setting.Key {
+
+		case "vcs.revision":
+
+			metadata.Commit = setting.Value
+		case "vcs.time":
+
+			metadata.CommitDate = setting.Value
+		}
+	}
+
+	return
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,239 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"runtime/debug"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func test_metadata() *clasp.ApplicationMetadata {
+
+	return &clasp.ApplicationMetadata{
+
+		Copyright:  "Copyright (c) 2026 Synesis Information Systems",
+		Licence:    "BSD-3-Clause",
+		Authors:    []string{"Matt Wilson", "Jane Doe"},
+		BuildDate:  "2026-10-19T09:30:00Z",
+		GoVersion:  "go1.23.6",
+		Commit:     "0123456789abcdef",
+		CommitDate: "2026-10-18T17:00:00Z",
+	}
+}
+
+func show_version_format_(t *testing.T, format clasp.VersionFormat, metadata *clasp.ApplicationMetadata) string {
+
+	t.Helper()
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowVersion(nil, clasp.UsageParams{
+
+		Stream:        stream,
+		ProgramName:   "myprog",
+		UsageFlags:    clasp.DontCallExit,
+		Version:       "1.2.3",
+		VersionPrefix: "v",
+		Metadata:      metadata,
+		VersionFormat: format,
+	})
+
+	require.Nil(t, err)
+
+	return stream.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowVersion_Short(t *testing.T) {
+
+	stegol.CheckStringEqual(t, "myprog v1.2.3\n", show_version_format_(t, clasp.VersionFormat_Short, test_metadata()))
+}
+
+func Test_ShowVersion_Long(t *testing.T) {
+
+	expected := "myprog v1.2.3\n" +
+		"Copyright (c) 2026 Synesis Information Systems\n" +
+		"Licence: BSD-3-Clause\n" +
+		"Authors: Matt Wilson, Jane Doe\n" +
+		"Build date: 2026-10-19T09:30:00Z\n" +
+		"Go version: go1.23.6\n" +
+		"Commit: 0123456789abcdef\n" +
+		"Commit date: 2026-10-18T17:00:00Z\n"
+
+	stegol.CheckStringEqual(t, expected, show_version_format_(t, clasp.VersionFormat_Long, test_metadata()))
+}
+
+func Test_ShowVersion_Long_localised(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowVersion(nil, clasp.UsageParams{
+
+		Stream:        stream,
+		ProgramName:   "myprog",
+		UsageFlags:    clasp.DontCallExit,
+		Version:       "1.2.3",
+		Metadata:      test_metadata(),
+		VersionFormat: clasp.VersionFormat_Long,
+		Locale:        "de_DE",
+		Messages:      clasp.MapMessageCatalogue{clasp.Message_CommitLabel: "Revision"},
+	})
+
+	require.Nil(t, err)
+
+	expected := "myprog 1.2.3\n" +
+		"Copyright (c) 2026 Synesis Information Systems\n" +
+		"Lizenz: BSD-3-Clause\n" +
+		"Autoren: Matt Wilson, Jane Doe\n" +
+		"Erstellungsdatum: 2026-10-19T09:30:00Z\n" +
+		"Go-Version: go1.23.6\n" +
+		"Revision: 0123456789abcdef\n" +
+		"Commit-Datum: 2026-10-18T17:00:00Z\n"
+
+	stegol.CheckStringEqual(t, expected, stream.String())
+}
+
+func Test_ShowVersion_Long_partial_metadata(t *testing.T) {
+
+	expected := "myprog v1.2.3\n" +
+		"Licence: MIT\n"
+
+	stegol.CheckStringEqual(t, expected, show_version_format_(t, clasp.VersionFormat_Long, &clasp.ApplicationMetadata{Licence: "MIT"}))
+	stegol.CheckStringEqual(t, "myprog v1.2.3\n", show_version_format_(t, clasp.VersionFormat_Long, nil))
+}
+
+func Test_ShowVersion_JSON(t *testing.T) {
+
+	expected := `{"program":"myprog","version":"1.2.3","copyright":"Copyright (c) 2026 Synesis Information Systems","licence":"BSD-3-Clause","authors":["Matt Wilson","Jane Doe"],"build_date":"2026-10-19T09:30:00Z","go_version":"go1.23.6","commit":"0123456789abcdef","commit_date":"2026-10-18T17:00:00Z"}` + "\n"
+
+	stegol.CheckStringEqual(t, expected, show_version_format_(t, clasp.VersionFormat_JSON, test_metadata()))
+	stegol.CheckStringEqual(t, `{"program":"myprog","version":"1.2.3"}`+"\n", show_version_format_(t, clasp.VersionFormat_JSON, nil))
+}
+
+func Test_ParseVersionFormat(t *testing.T) {
+
+	for s, expected := range map[string]clasp.VersionFormat{
+
+		"short": clasp.VersionFormat_Short,
+		"long":  clasp.VersionFormat_Long,
+		"JSON":  clasp.VersionFormat_JSON,
+	} {
+
+		format, err := clasp.ParseVersionFormat(s)
+
+		require.Nil(t, err)
+		require.Equal(t, expected, format)
+	}
+
+	_, err := clasp.ParseVersionFormat("yaml")
+
+	require.NotNil(t, err)
+	require.Equal(t, `unrecognised version format "yaml": must be one of short, long, json`, err.Error())
+}
+
+func Test_LookupVersionFormat(t *testing.T) {
+
+	specifications := []clasp.Specification{clasp.VersionFlag()}
+
+	for _, tc := range []struct {
+		argv     []string
+		format   clasp.VersionFormat
+		found    bool
+		is_error bool
+	}{
+		{[]string{"myprog"}, clasp.VersionFormat_Short, false, false},
+		{[]string{"myprog", "--version"}, clasp.VersionFormat_Short, true, false},
+		{[]string{"myprog", "--version=long"}, clasp.VersionFormat_Long, true, false},
+		{[]string{"myprog", "--version=json"}, clasp.VersionFormat_JSON, true, false},
+		{[]string{"myprog", "--version=xml"}, clasp.VersionFormat_Short, true, true},
+	} {
+
+		args := clasp.Parse(tc.argv, clasp.ParseParams{Specifications: specifications})
+
+		format, found, err := args.LookupVersionFormat()
+
+		require.Equal(t, tc.format, format, "for %v", tc.argv)
+		require.Equal(t, tc.found, found, "for %v", tc.argv)
+		require.Equal(t, tc.is_error, nil != err, "for %v", tc.argv)
+		require.Nil(t, args.UnusedFlagsAndOptionsError())
+	}
+}
+
+func Test_ShowUsage_metadata_info_lines(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowUsage(nil, clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit,
+		Version:     "1.2.3",
+		Metadata:    &clasp.ApplicationMetadata{Copyright: "Copyright (c) 2026 Synesis", Licence: "BSD-3-Clause"},
+		InfoLines:   []string{":version:", ":copyright:", ":licence:", ":authors:", ""},
+	})
+
+	require.Nil(t, err)
+
+	expected := "myprog 1.2.3\n" +
+		"Copyright (c) 2026 Synesis\n" +
+		"Licence: BSD-3-Clause\n" +
+		"\n" +
+		"USAGE: myprog\n"
+
+	stegol.CheckStringEqual(t, expected, stream.String())
+
+	stream.Reset()
+
+	_, err = clasp.ShowUsage(nil, clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit,
+		Metadata:    &clasp.ApplicationMetadata{Licence: "BSD-3-Clause", Authors: []string{"Matt Wilson"}},
+		InfoLines:   []string{":licence:", ":authors:", ""},
+		Locale:      "ja",
+	})
+
+	require.Nil(t, err)
+
+	expected = "ライセンス: BSD-3-Clause\n" +
+		"作者: Matt Wilson\n" +
+		"\n" +
+		"使い方: myprog\n"
+
+	stegol.CheckStringEqual(t, expected, stream.String())
+}
+
+func Test_ApplicationMetadataFromBuildInfo(t *testing.T) {
+
+	metadata := clasp.ApplicationMetadataFromBuildInfo(func() (*debug.BuildInfo, bool) {
+
+		return &debug.BuildInfo{
+
+			GoVersion: "go1.23.6",
+			Settings: []debug.BuildSetting{
+
+				{Key: "vcs", Value: "git"},
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.time", Value: "2026-10-19T09:30:00Z"},
+			},
+		}, true
+	})
+
+	require.Equal(t, clasp.ApplicationMetadata{GoVersion: "go1.23.6", Commit: "0123456789abcdef", CommitDate: "2026-10-19T09:30:00Z"}, metadata)
+
+	require.Equal(t, clasp.ApplicationMetadata{}, clasp.ApplicationMetadataFromBuildInfo(func() (*debug.BuildInfo, bool) { return nil, false }))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
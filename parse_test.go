@@ -1150,3 +1150,225 @@ func Test_flag_alias_of_option_with_value(t *testing.T) {
 		check(t, "high" == option0.Value, "arguments has wrong value")
 	}
 }
+
+func Test_Parse_DoubleHyphen_boundary(t *testing.T) {
+
+	argv := []string{"mytool", "run", "-v", "--", "cmd", "--its-own-flag", "--", "x"}
+
+	args := clasp.Parse(argv, clasp.ParseParams{})
+
+	require.Equal(t, 3, args.DoubleHyphenIndex)
+	require.Equal(t, []string{"cmd", "--its-own-flag", "--", "x"}, args.Passthrough())
+
+	require.Equal(t, 1, len(args.Flags))
+	require.Equal(t, 5, len(args.Values))
+
+	require.False(t, args.Values[0].AfterDoubleHyphen)
+	for _, arg := range args.Values[1:] {
+
+		require.True(t, arg.AfterDoubleHyphen, "for '%s'", arg.Value)
+	}
+
+	// the passthrough is a copy
+
+	args.Passthrough()[0] = "changed"
+
+	require.Equal(t, "cmd", args.Passthrough()[0])
+}
+
+func Test_Parse_DoubleHyphen_none(t *testing.T) {
+
+	args := clasp.Parse([]string{"mytool", "run", "-v"}, clasp.ParseParams{})
+
+	require.Equal(t, -1, args.DoubleHyphenIndex)
+	require.Nil(t, args.Passthrough())
+
+	args = clasp.Parse([]string{"mytool", "--", "-v"}, clasp.ParseParams{Flags: clasp.Parse_DontRecogniseDoubleHyphenToStartValues})
+
+	require.Equal(t, -1, args.DoubleHyphenIndex)
+	require.Nil(t, args.Passthrough())
+
+	args = clasp.Parse([]string{"mytool", "--"}, clasp.ParseParams{})
+
+	require.Equal(t, 1, args.DoubleHyphenIndex)
+	require.Equal(t, []string{}, args.Passthrough())
+}
+
+func Test_Parse_DoubleHyphen_between_option_and_value(t *testing.T) {
+
+	args := clasp.Parse([]string{"mytool", "--verbosity", "--", "chatty", "x"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{clasp.Option("--verbosity")},
+	})
+
+	require.Equal(t, 2, args.DoubleHyphenIndex)
+	require.Equal(t, "chatty", args.Options[0].Value)
+	require.Equal(t, []string{"chatty", "x"}, args.Passthrough())
+}
+
+func Test_Parse_StopAtFirstValue(t *testing.T) {
+
+	argv := []string{"mytool", "-v", "--verbosity", "chatty", "cmd", "-x", "--", "--verbose", "y"}
+	params := clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--verbose").SetAlias("-v"),
+			clasp.Option("--verbosity"),
+		},
+		Flags: clasp.Parse_StopAtFirstValue,
+	}
+
+	args := clasp.Parse(argv, params)
+
+	require.Equal(t, 1, len(args.Flags))
+	require.Equal(t, 1, len(args.Options))
+	require.Equal(t, "chatty", args.Options[0].Value)
+	require.Equal(t, 5, len(args.Values))
+	require.Equal(t, -1, args.DoubleHyphenIndex)
+	require.Equal(t, []string{"cmd", "-x", "--", "--verbose", "y"}, args.Remainder())
+	require.Nil(t, args.Passthrough())
+
+	for _, arg := range args.Values {
+
+		require.False(t, arg.AfterDoubleHyphen, "for '%s'", arg.Value)
+		require.Equal(t, 0, arg.NumGivenHyphens, "for '%s'", arg.Value)
+	}
+
+	// without the flag, option processing continues past the first value
+
+	params.Flags = clasp.Parse_None
+
+	args = clasp.Parse(argv, params)
+
+	require.Equal(t, 2, len(args.Flags))
+	require.Equal(t, 6, args.DoubleHyphenIndex)
+	require.Equal(t, []string{"--verbose", "y"}, args.Remainder())
+	require.Equal(t, args.Passthrough(), args.Remainder())
+}
+
+func Test_Parse_StopAtFirstValue_no_values(t *testing.T) {
+
+	args := clasp.Parse([]string{"mytool", "-v", "--x"}, clasp.ParseParams{Flags: clasp.Parse_StopAtFirstValue})
+
+	require.Equal(t, 2, len(args.Flags))
+	require.Nil(t, args.Remainder())
+
+	// a double hyphen before any value still starts the remainder
+
+	args = clasp.Parse([]string{"mytool", "-v", "--", "-x"}, clasp.ParseParams{Flags: clasp.Parse_StopAtFirstValue})
+
+	require.Equal(t, 2, args.DoubleHyphenIndex)
+	require.Equal(t, []string{"-x"}, args.Remainder())
+	require.True(t, args.Values[0].AfterDoubleHyphen)
+
+	// a single hyphen treated as a value is the first value
+
+	args = clasp.Parse([]string{"mytool", "-", "-v"}, clasp.ParseParams{Flags: clasp.Parse_StopAtFirstValue | clasp.Parse_TreatSingleHyphenAsValue})
+
+	require.Equal(t, 0, len(args.Flags))
+	require.Equal(t, []string{"-", "-v"}, args.Remainder())
+}
+
+func Test_Parse_HonourPosixlyCorrect(t *testing.T) {
+
+	argv := []string{"mytool", "cmd", "-v"}
+
+	lookupEnv := func(env map[string]string) func(string) (string, bool) {
+
+		return func(key string) (string, bool) {
+
+			v, ok := env[key]
+
+			return v, ok
+		}
+	}
+
+	args := clasp.Parse(argv, clasp.ParseParams{
+
+		Flags:     clasp.Parse_HonourPosixlyCorrect,
+		LookupEnv: lookupEnv(map[string]string{}),
+	})
+
+	require.Equal(t, 1, len(args.Flags))
+	require.Nil(t, args.Remainder())
+
+	// defined, even if empty
+
+	args = clasp.Parse(argv, clasp.ParseParams{
+
+		Flags:     clasp.Parse_HonourPosixlyCorrect,
+		LookupEnv: lookupEnv(map[string]string{"POSIXLY_CORRECT": ""}),
+	})
+
+	require.Equal(t, 0, len(args.Flags))
+	require.Equal(t, []string{"cmd", "-v"}, args.Remainder())
+
+	// not honoured unless requested
+
+	args = clasp.Parse(argv, clasp.ParseParams{
+
+		LookupEnv: lookupEnv(map[string]string{"POSIXLY_CORRECT": "1"}),
+	})
+
+	require.Equal(t, 1, len(args.Flags))
+}
+
+func Test_Parse_StopAtFirstValue_ToArgv_round_trips(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v"),
+		clasp.Option("--verbosity"),
+	}
+
+	values_ := func(args *clasp.Arguments) (values []string) {
+
+		for _, arg := range args.Values {
+
+			values = append(values, arg.Value)
+		}
+
+		return
+	}
+
+	for _, params := range []clasp.ParseParams{
+
+		{Specifications: specifications, Flags: clasp.Parse_StopAtFirstValue},
+		{Specifications: specifications, Flags: clasp.Parse_HonourPosixlyCorrect, LookupEnv: func(key string) (string, bool) { return "", "POSIXLY_CORRECT" == key }},
+	} {
+
+		for _, argv := range [][]string{
+
+			{"mytool", "-v", "cmd", "-x", "--", "y"},
+			{"mytool", "--verbosity", "chatty", "cmd", "--verbose"},
+			{"mytool", "-v", "--", "-x", "--", "y"},
+			{"mytool", "-v"},
+		} {
+
+			args := clasp.Parse(argv, params)
+
+			reparsed := clasp.Parse(append([]string{"mytool"}, args.ToArgv(clasp.ToArgvOptions{})...), params)
+
+			require.Equal(t, values_(args), values_(reparsed), "for %v", argv)
+			require.Equal(t, len(args.Flags), len(reparsed.Flags), "for %v", argv)
+			require.Equal(t, len(args.Options), len(reparsed.Options), "for %v", argv)
+			require.Equal(t, args.Remainder(), reparsed.Remainder(), "for %v", argv)
+		}
+	}
+}
+
+func Test_ParseFunc_StopAtFirstValue(t *testing.T) {
+
+	var types []clasp.ArgType
+
+	err := clasp.ParseFunc([]string{"mytool", "-v", "cmd", "-x"}, clasp.ParseParams{Flags: clasp.Parse_StopAtFirstValue}, func(arg *clasp.Argument) error {
+
+		types = append(types, arg.Type)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []clasp.ArgType{clasp.FlagType, clasp.ValueType, clasp.ValueType}, types)
+}
@@ -0,0 +1,156 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"unicode/utf8"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// A precompiled parser, which validates its specifications once, and
+// indexes them by name, by alias, and by short (single character) name,
+// such that repeated parsing takes time proportional to the number of
+// arguments rather than to the number of arguments and specifications.
+//
+// A Parser may be used concurrently from multiple goroutines, except that
+// the flags receiver variables of any specifications (see
+// [Specification.SetBitFlags] and [Specification.SetBitFlags64]) are
+// updated by each parse without synchronisation.
+//
+// The specification of each parsed argument (see
+// [Argument.ArgumentSpecification]) is that of the parser, and so must not
+// be modified.
+type Parser struct {
+	params         ParseParams
+	specifications []*Specification
+	names          map[string]int
+	aliases        map[string]int
+	shorts         map[rune]int
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func (parser *Parser) found(ix int) (found bool, specification *Specification, specificationIndex int) {
+
+	// the parser's own specification is returned, rather than a copy (as
+	// by [ParseParams.findSpecification]), since it is not modified once
+	// the parser is created
+
+	return true, parser.specifications[ix], ix
+}
+
+func (parser *Parser) findSpecification(name string) (found bool, specification *Specification, specificationIndex int) {
+
+	if ix, ok := parser.names[name]; ok {
+
+		return parser.found(ix)
+	}
+
+	if ix, ok := parser.aliases[name]; ok {
+
+		return parser.found(ix)
+	}
+
+	return false, nil, -1
+}
+
+func (parser *Parser) findShortSpecification(c rune) (found bool, specification *Specification, specificationIndex int) {
+
+	if ix, ok := parser.shorts[c]; ok {
+
+		return parser.found(ix)
+	}
+
+	return false, nil, -1
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Creates a parser for the given parameters, having validated their
+// specifications (see [ValidateSpecifications]), returning an error if
+// they are invalid.
+//
+// The specifications are copied, so subsequent changes to
+// params.Specifications do not affect the parser.
+func NewParser(params ParseParams) (*Parser, error) {
+
+	if err := ValidateSpecifications(params.Specifications); err != nil {
+
+		return nil, err
+	}
+
+	params.Specifications = append([]Specification(nil), params.Specifications...)
+
+	parser := &Parser{
+
+		params:         params,
+		specifications: make([]*Specification, len(params.Specifications)),
+		names:          make(map[string]int, len(params.Specifications)),
+		aliases:        make(map[string]int),
+		shorts:         make(map[rune]int),
+	}
+
+	// as for [ParseParams.findSpecification], the first specification
+	// with a given name, or else with a given alias, is matched
+
+	for i, spec := range params.Specifications {
+
+		parser.specifications[i] = &params.Specifications[i]
+
+		if _, exists := parser.names[spec.Name]; !exists {
+
+			parser.names[spec.Name] = i
+		}
+
+		for _, alias := range spec.Aliases {
+
+			if _, exists := parser.aliases[alias]; !exists {
+
+				parser.aliases[alias] = i
+			}
+		}
+	}
+
+	for _, m := range []map[string]int{parser.names, parser.aliases} {
+
+		for name := range m {
+
+			if 2 == utf8.RuneCountInString(name) && '-' == name[0] {
+
+				c, _ := utf8.DecodeRuneInString(name[1:])
+
+				if _, _, ix := parser.findSpecification(name); ix >= 0 {
+
+					parser.shorts[c] = ix
+				}
+			}
+		}
+	}
+
+	return parser, nil
+}
+
+// Parses the given arguments, with the same results as [Parse] given the
+// parameters of the parser, except that [Parse_ValidateSpecifications] is
+// redundant.
+func (parser *Parser) Parse(argv []string) *Arguments {
+
+	return parse_(argv, &parser.params, parser, parser.specifications)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
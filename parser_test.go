@@ -0,0 +1,217 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"fmt"
+	"sync"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func parser_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Flag("--debug").SetAlias("-d"),
+		clasp.Flag("--quiet").SetAlias("-q"),
+		clasp.Option("--verbosity").SetAlias("-V").SetValues("terse", "chatty"),
+		clasp.AliasesFor("--verbosity=chatty", "-c"),
+		clasp.HelpFlag(),
+	}
+}
+
+// Obtains n flags and n options, each with a long alias and, where
+// available, a short alias.
+func large_specifications(n int) []clasp.Specification {
+
+	var specifications []clasp.Specification
+
+	for i := 0; i != n; i++ {
+
+		specifications = append(specifications, clasp.Flag(fmt.Sprintf("--flag-%d", i)).SetAlias(fmt.Sprintf("--f%d", i)))
+		specifications = append(specifications, clasp.Option(fmt.Sprintf("--option-%d", i)).SetAlias(fmt.Sprintf("--o%d", i)))
+	}
+
+	for i, c := range "abcdefghijklmnopqrstuvwxyz" {
+
+		specifications[2*i].Aliases = append(specifications[2*i].Aliases, fmt.Sprintf("-%c", c))
+	}
+
+	return specifications
+}
+
+func large_argv(n int) []string {
+
+	argv := []string{"myprog"}
+
+	for i := 0; i != n; i++ {
+
+		argv = append(argv, fmt.Sprintf("--f%d", n-1-i), fmt.Sprintf("--option-%d=value", i), "-xyz", fmt.Sprintf("value-%d", i))
+	}
+
+	return argv
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_NewParser_invalid_specifications(t *testing.T) {
+
+	parser, err := clasp.NewParser(clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--debug"),
+			clasp.Flag("--debug"),
+		},
+	})
+
+	require.Nil(t, parser)
+	require.NotNil(t, err)
+}
+
+func Test_Parser_Parse_same_as_Parse(t *testing.T) {
+
+	params := clasp.ParseParams{Specifications: parser_specifications()}
+
+	parser, err := clasp.NewParser(params)
+
+	require.Nil(t, err)
+
+	for _, argv := range [][]string{
+
+		{"myprog"},
+		{"myprog", "-dq", "--verbosity", "terse", "value"},
+		{"myprog", "-V=chatty", "-c", "--help", "-", "--", "--debug"},
+		{"myprog", "-dqc", "-dx", "--unknown=1"},
+	} {
+
+		require.Equal(t, clasp.Parse(argv, params), parser.Parse(argv), "for %v", argv)
+	}
+}
+
+func Test_Parser_Parse_large_same_as_Parse(t *testing.T) {
+
+	params := clasp.ParseParams{Specifications: large_specifications(100)}
+
+	parser, err := clasp.NewParser(params)
+
+	require.Nil(t, err)
+
+	argv := large_argv(100)
+
+	require.Equal(t, clasp.Parse(argv, params), parser.Parse(argv))
+}
+
+func Test_Parser_unaffected_by_changes_to_specifications(t *testing.T) {
+
+	params := clasp.ParseParams{Specifications: parser_specifications()}
+
+	parser, err := clasp.NewParser(params)
+
+	require.Nil(t, err)
+
+	params.Specifications[0] = clasp.Flag("--changed")
+
+	args := parser.Parse([]string{"myprog", "-d"})
+
+	require.True(t, args.FlagIsSpecified("--debug"))
+}
+
+func Test_Parser_Parse_concurrently(t *testing.T) {
+
+	parser, err := clasp.NewParser(clasp.ParseParams{Specifications: parser_specifications()})
+
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i != 8; i++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+
+			for j := 0; j != 100; j++ {
+
+				args := parser.Parse([]string{"myprog", "-dq", "-c", "value"})
+
+				if !args.FlagIsSpecified("--debug") || 1 != len(args.Options) || "chatty" != args.Options[0].Value {
+
+					t.Error("unexpected parse result")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func Test_Parser_ParseFunc_found_specification_does_not_allocate(t *testing.T) {
+
+	parser, err := clasp.NewParser(clasp.ParseParams{Specifications: parser_specifications()})
+
+	require.Nil(t, err)
+
+	allocs := func(argv ...string) float64 {
+
+		return testing.AllocsPerRun(100, func() {
+
+			parser.ParseFunc(argv, func(arg *clasp.Argument) error {
+
+				return nil
+			})
+		})
+	}
+
+	// a found specification should cost no more than one not found
+
+	require.LessOrEqual(t, allocs("myprog", "--debug", "--debug", "-q", "-q"), allocs("myprog", "--unknown", "--unknown", "-u", "-u"))
+	require.LessOrEqual(t, allocs("myprog", "--verbosity=terse", "--verbosity=terse"), allocs("myprog", "--unknown=x", "--unknown=x"))
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * benchmarks
+ */
+
+func Benchmark_Parse_large(b *testing.B) {
+
+	params := clasp.ParseParams{Specifications: large_specifications(500)}
+	argv := large_argv(50)
+
+	b.ResetTimer()
+
+	for i := 0; i != b.N; i++ {
+
+		clasp.Parse(argv, params)
+	}
+}
+
+func Benchmark_Parser_Parse_large(b *testing.B) {
+
+	parser, err := clasp.NewParser(clasp.ParseParams{Specifications: large_specifications(500)})
+	if err != nil {
+
+		b.Fatal(err)
+	}
+
+	argv := large_argv(50)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i != b.N; i++ {
+
+		parser.Parse(argv)
+	}
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
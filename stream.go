@@ -0,0 +1,89 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"errors"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// Value that may be returned by the function passed to [ParseFunc] to stop
+// parsing, whereupon [ParseFunc] returns `nil`.
+var StopParsing = errors.New("stop parsing")
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func parse_func_(argv []string, params *ParseParams, finder specification_finder, fn func(arg *Argument) error) error {
+
+	err := parse_arguments_(argv, params, finder, nil, func(arg *Argument) error {
+
+		if FlagType == arg.Type {
+
+			apply_bit_flags(arg, params.Flags)
+		}
+
+		return fn(arg)
+	})
+
+	if errors.Is(err, StopParsing) {
+
+		return nil
+	}
+
+	return err
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Parses the given arguments in the same manner as [Parse], but rather
+// than obtaining all the arguments, invokes the given function for each
+// argument as it is recognised, in command-line order, such that
+// arbitrarily many arguments may be processed in constant memory.
+//
+// The argument passed to fn is valid only for the duration of the call,
+// being reused for subsequent arguments (such that no allocation is made
+// for values), so must be copied if it is to be retained. Flags receiver
+// variables (see [Specification.SetBitFlags]) are updated before fn is
+// called for the corresponding flag.
+//
+// If fn returns an error, parsing stops and the error is returned, except
+// for [StopParsing], for which `nil` is returned. If
+// [Parse_ValidateSpecifications] is specified and the specifications are
+// invalid, the error obtained from [ValidateSpecifications] is returned
+// before any argument is parsed.
+func ParseFunc(argv []string, params ParseParams, fn func(arg *Argument) error) error {
+
+	if 0 != (Parse_ValidateSpecifications & params.Flags) {
+
+		if err := ValidateSpecifications(params.Specifications); err != nil {
+
+			return err
+		}
+	}
+
+	return parse_func_(argv, &params, &params, fn)
+}
+
+// Parses the given arguments in the same manner as [ParseFunc], given the
+// parameters of the parser.
+func (parser *Parser) ParseFunc(argv []string, fn func(arg *Argument) error) error {
+
+	return parse_func_(argv, &parser.params, parser, fn)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,235 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func collect_(t *testing.T, argv []string, params clasp.ParseParams) []clasp.Argument {
+
+	t.Helper()
+
+	var arguments []clasp.Argument
+
+	err := clasp.ParseFunc(argv, params, func(arg *clasp.Argument) error {
+
+		arguments = append(arguments, *arg)
+
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	return arguments
+}
+
+// Obtains a copy of the argument comprising only its exported fields, since
+// its use state is not shared with a copy made by a ParseFunc() callback.
+func exported_fields_(arg clasp.Argument) clasp.Argument {
+
+	return clasp.Argument{
+
+		ResolvedName:          arg.ResolvedName,
+		GivenName:             arg.GivenName,
+		Value:                 arg.Value,
+		Type:                  arg.Type,
+		CmdLineIndex:          arg.CmdLineIndex,
+		NumGivenHyphens:       arg.NumGivenHyphens,
+		ArgumentSpecification: arg.ArgumentSpecification,
+		Flags:                 arg.Flags,
+		AfterDoubleHyphen:     arg.AfterDoubleHyphen,
+		Deprecated:            arg.Deprecated,
+		CharOffset:            arg.CharOffset,
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ParseFunc_same_as_Parse(t *testing.T) {
+
+	params := clasp.ParseParams{Specifications: parser_specifications()}
+
+	for _, argv := range [][]string{
+
+		{"myprog"},
+		{"myprog", "-dq", "--verbosity", "terse", "value"},
+		{"myprog", "-V=chatty", "-c", "--help", "-", "--", "--debug"},
+		{"myprog", "-dqc", "-dx", "--unknown=1"},
+		{"myprog", "value", "--verbosity"},
+	} {
+
+		var expected []clasp.Argument
+		var actual []clasp.Argument
+
+		for _, arg := range clasp.Parse(argv, params).Arguments {
+
+			expected = append(expected, exported_fields_(*arg))
+		}
+
+		for _, arg := range collect_(t, argv, params) {
+
+			actual = append(actual, exported_fields_(arg))
+		}
+
+		require.Equal(t, expected, actual, "for %v", argv)
+	}
+}
+
+func Test_ParseFunc_applies_bit_flags(t *testing.T) {
+
+	var flags int
+
+	arguments := collect_(t, []string{"myprog", "-a", "value", "--bee"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--ay").SetAlias("-a").SetBitFlags(0x1, &flags),
+			clasp.Flag("--bee").SetBitFlags(0x2, &flags),
+		},
+	})
+
+	require.Equal(t, 3, len(arguments))
+	require.Equal(t, 0x3, flags)
+}
+
+func Test_ParseFunc_StopParsing(t *testing.T) {
+
+	var values []string
+
+	err := clasp.ParseFunc([]string{"myprog", "a", "b", "--stop", "c"}, clasp.ParseParams{}, func(arg *clasp.Argument) error {
+
+		if "--stop" == arg.ResolvedName {
+
+			return clasp.StopParsing
+		}
+
+		values = append(values, arg.Value)
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"a", "b"}, values)
+}
+
+func Test_ParseFunc_error(t *testing.T) {
+
+	err := clasp.ParseFunc([]string{"myprog", "a", "--bad", "c"}, clasp.ParseParams{}, func(arg *clasp.Argument) error {
+
+		if clasp.FlagType == arg.Type {
+
+			return fmt.Errorf("unexpected flag '%s'", arg.ResolvedName)
+		}
+
+		return nil
+	})
+
+	require.NotNil(t, err)
+	require.Equal(t, "unexpected flag '--bad'", err.Error())
+}
+
+func Test_ParseFunc_ValidateSpecifications(t *testing.T) {
+
+	called := false
+
+	err := clasp.ParseFunc([]string{"myprog", "a"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{clasp.Flag("debug")},
+		Flags:          clasp.Parse_ValidateSpecifications,
+	}, func(arg *clasp.Argument) error {
+
+		called = true
+
+		return nil
+	})
+
+	var se *clasp.SpecificationError
+
+	require.True(t, errors.As(err, &se))
+	require.False(t, called)
+}
+
+func Test_ParseFunc_constant_memory(t *testing.T) {
+
+	params := clasp.ParseParams{Specifications: parser_specifications()}
+
+	allocs := func(num_values int) float64 {
+
+		argv := []string{"myprog", "-d"}
+
+		for i := 0; i != num_values; i++ {
+
+			argv = append(argv, fmt.Sprintf("file-%d.txt", i))
+		}
+
+		num := 0
+
+		return testing.AllocsPerRun(10, func() {
+
+			clasp.ParseFunc(argv, params, func(arg *clasp.Argument) error {
+
+				num++
+
+				return nil
+			})
+		})
+	}
+
+	require.Equal(t, allocs(10), allocs(10000))
+}
+
+func Test_Parser_ParseFunc(t *testing.T) {
+
+	parser, err := clasp.NewParser(clasp.ParseParams{Specifications: parser_specifications()})
+
+	require.Nil(t, err)
+
+	var names []string
+
+	err = parser.ParseFunc([]string{"myprog", "-dc", "value"}, func(arg *clasp.Argument) error {
+
+		names = append(names, arg.Str())
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"--debug", "--verbosity=chatty", ""}, names)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * benchmarks
+ */
+
+func Benchmark_ParseFunc_values(b *testing.B) {
+
+	argv := []string{"myprog"}
+
+	for i := 0; i != 100000; i++ {
+
+		argv = append(argv, fmt.Sprintf("file-%d.txt", i))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i != b.N; i++ {
+
+		clasp.ParseFunc(argv, clasp.ParseParams{}, func(arg *clasp.Argument) error {
+
+			return nil
+		})
+	}
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
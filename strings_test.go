@@ -182,7 +182,7 @@ func Test_String_of_UsageParams_1(t *testing.T) {
 
 	parseParams := clasp.UsageParams{}
 
-	expected := "<clasp.UsageParams{ Stream=<nil>, ProgramName=\"\", UsageFlags=0x0, ExitCode=0, Exiter=<nil>, Version=<nil>, VersionPrefix=\"\", InfoLines=[], ValuesString=\"\", FlagsAndOptionsString=\"\" }>"
+	expected := "<clasp.UsageParams{ Stream=<nil>, ProgramName=\"\", UsageFlags=0x0, ExitCode=0, Exiter=<nil>, Version=<nil>, VersionPrefix=\"\", InfoLines=[], ValuesString=\"\", FlagsAndOptionsString=\"\", Width=0, Template=<nil>, Messages=<nil>, Locale=\"\" }>"
 	actual := parseParams.String()
 
 	stegol.CheckStringEqual(t, expected, actual)
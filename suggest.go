@@ -0,0 +1,249 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"sort"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+type suggestion_candidate struct {
+	suggestion string
+	distance   int
+	order      int
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Obtains the optimal string alignment distance between two strings, i.e.
+// the Levenshtein distance with the addition of transposition of adjacent
+// characters, e.g. "verbsoe" to "verbose" is 1.
+func edit_distance(s1, s2 string) int {
+
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	// d[i][j] is the distance between r1[:i] and r2[:j]
+
+	d := make([][]int, len(r1)+1)
+
+	for i := range d {
+
+		d[i] = make([]int, len(r2)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+
+		for j := 1; j <= len(r2); j++ {
+
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] && r1[i-2] == r2[j-1] {
+
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(r1)][len(r2)]
+}
+
+// Obtains the distance between the given name and a candidate, ignoring
+// leading hyphens and case, and whether it is close enough to suggest.
+func suggestion_distance(given, candidate string) (int, bool) {
+
+	g := strings.ToLower(strings.TrimLeft(given, "-"))
+	c := strings.ToLower(strings.TrimLeft(candidate, "-"))
+
+	if 0 == len(g) || 0 == len(c) {
+
+		return 0, false
+	}
+
+	distance := edit_distance(g, c)
+	threshold := max(1, len([]rune(g))/3)
+
+	// a candidate that must be (all but) entirely rewritten is no suggestion
+
+	if distance > threshold || distance >= len([]rune(g)) || distance >= len([]rune(c)) {
+
+		return 0, false
+	}
+
+	return distance, true
+}
+
+func rank_suggestions(candidates []suggestion_candidate) []string {
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+
+		if candidates[i].distance != candidates[j].distance {
+
+			return candidates[i].distance < candidates[j].distance
+		}
+
+		return candidates[i].order < candidates[j].order
+	})
+
+	var suggestions []string
+
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+
+		if !seen[candidate.suggestion] {
+
+			seen[candidate.suggestion] = true
+
+			suggestions = append(suggestions, candidate.suggestion)
+		}
+	}
+
+	return suggestions
+}
+
+func (args *Arguments) suggest_names(given string) []string {
+
+	var candidates []suggestion_candidate
+
+	add := func(name string) {
+
+		if distance, ok := suggestion_distance(given, name); ok {
+
+			candidates = append(candidates, suggestion_candidate{name, distance, len(candidates)})
+		}
+	}
+
+	for _, specification := range args.specifications {
+
+		switch specification.Type {
+
+		case FlagType, OptionType:
+
+			if !is_offered(*specification) {
+
+				continue
+			}
+
+			// the name of an option-value alias - e.g. "--verbosity=chatty"
+			// - is not itself a valid flag
+
+			if !strings.Contains(specification.Name, "=") {
+
+				add(specification.Name)
+			}
+			for _, alias := range specification.Aliases {
+
+				add(alias)
+			}
+		}
+	}
+
+	return rank_suggestions(candidates)
+}
+
+func suggest_values(arg *Argument) []string {
+
+	specification := arg.ArgumentSpecification
+
+	for _, value := range specification.ValueSet {
+
+		if value == arg.Value {
+
+			return nil
+		}
+	}
+
+	var candidates []suggestion_candidate
+
+	for i, value := range specification.ValueSet {
+
+		if distance, ok := suggestion_distance(arg.Value, value); ok {
+
+			candidates = append(candidates, suggestion_candidate{arg.ResolvedName + "=" + value, distance, i})
+		}
+	}
+
+	return rank_suggestions(candidates)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Obtains suggestions for a mistyped argument, most likely first, or `nil`
+// if there are none:
+//
+//   - for a flag or option that does not match any specification - e.g.
+//     "--verbsoe" - the names and aliases of the flag and option
+//     specifications given to [Parse] that are within a small edit
+//     distance - allowing transposition and ignoring case and leading
+//     hyphens - e.g. "--verbose";
+//   - for an option whose value is not in its [Specification.ValueSet] -
+//     e.g. "--verbosity=chaty" - the option with the close values, e.g.
+//     "--verbosity=chatty".
+func (args *Arguments) Suggest(arg *Argument) []string {
+
+	switch arg.Type {
+
+	case FlagType, OptionType:
+
+		if nil == arg.ArgumentSpecification {
+
+			return args.suggest_names(arg.GivenName)
+		}
+
+		if OptionType == arg.Type && OptionType == arg.ArgumentSpecification.Type {
+
+			return suggest_values(arg)
+		}
+	}
+
+	return nil
+}
+
+// Obtains a hint line - e.g. "did you mean --verbose?" - for the
+// suggestions (see [Arguments.Suggest]) for a mistyped argument, in the
+// locale specified to [Parse] (see [ParseParams.Locale]), or the empty
+// string if there are none.
+func (args *Arguments) SuggestionHint(arg *Argument) string {
+
+	return format_suggestion_hint(args.messages, args.locale, args.Suggest(arg))
+}
+
+func format_suggestion_hint(catalogue MessageCatalogue, locale string, suggestions []string) string {
+
+	if 0 == len(suggestions) {
+
+		return ""
+	}
+
+	return substitute_arguments(lookup_message(catalogue, locale, Message_DidYouMean), strings.Join(suggestions, ", "))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
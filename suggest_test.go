@@ -0,0 +1,104 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func suggest_specifications() []clasp.Specification {
+
+	return []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v"),
+		clasp.Flag("--version"),
+		clasp.Flag("--dry-run").SetAlias("-n"),
+		clasp.Option("--verbosity").SetAlias("-V").SetValues("terse", "chatty", "silent"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+	}
+}
+
+func suggest_(t *testing.T, arg string) ([]string, string) {
+
+	t.Helper()
+
+	args := clasp.Parse([]string{"myprog", arg}, clasp.ParseParams{Specifications: suggest_specifications()})
+
+	require.Equal(t, 1, len(args.Arguments))
+
+	return args.Suggest(args.Arguments[0]), args.SuggestionHint(args.Arguments[0])
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_Suggest_transposition(t *testing.T) {
+
+	suggestions, hint := suggest_(t, "--verbsoe")
+
+	require.Equal(t, []string{"--verbose"}, suggestions)
+	require.Equal(t, "did you mean --verbose?", hint)
+}
+
+func Test_Suggest_ranked_by_distance(t *testing.T) {
+
+	suggestions, hint := suggest_(t, "--verbosty")
+
+	require.Equal(t, []string{"--verbosity", "--verbose"}, suggestions)
+	require.Equal(t, "did you mean --verbosity, --verbose?", hint)
+}
+
+func Test_Suggest_ignores_hyphens_and_case(t *testing.T) {
+
+	suggestions, _ := suggest_(t, "-Dry-Run")
+
+	require.Equal(t, []string{"--dry-run"}, suggestions)
+}
+
+func Test_Suggest_unknown_option_name(t *testing.T) {
+
+	suggestions, _ := suggest_(t, "--verbocity=chatty")
+
+	require.Equal(t, []string{"--verbosity"}, suggestions)
+}
+
+func Test_Suggest_option_value(t *testing.T) {
+
+	suggestions, hint := suggest_(t, "--verbosity=chaty")
+
+	require.Equal(t, []string{"--verbosity=chatty"}, suggestions)
+	require.Equal(t, "did you mean --verbosity=chatty?", hint)
+}
+
+func Test_Suggest_none(t *testing.T) {
+
+	for _, arg := range []string{"--verbose", "--verbosity=terse", "--xyz", "-x", "value"} {
+
+		suggestions, hint := suggest_(t, arg)
+
+		require.Nil(t, suggestions, "for '%s'", arg)
+		require.Equal(t, "", hint, "for '%s'", arg)
+	}
+}
+
+func Test_UnusedFlagsAndOptionsError_with_suggestions(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "--dyr-run", "--xyz"}, clasp.ParseParams{Specifications: suggest_specifications()})
+
+	require.Equal(t, "myprog: unrecognised flag/option: --dyr-run; did you mean --dry-run?\nmyprog: unrecognised flag/option: --xyz", args.UnusedFlagsAndOptionsError().Error())
+}
+
+func Test_UnusedFlagsAndOptionsError_with_suggestions_German(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "--dyr-run"}, clasp.ParseParams{Specifications: suggest_specifications(), Locale: "de"})
+
+	require.Equal(t, "myprog: unbekannter Schalter bzw. unbekannte Option: --dyr-run; meinten Sie --dry-run?", args.UnusedFlagsAndOptionsError().Error())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
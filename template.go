@@ -0,0 +1,169 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// The data passed to a usage template (see [UsageParams.Template]).
+type UsageData struct {
+	ProgramName            string         // The program name (see [UsageParams.ProgramName]).
+	Version                string         // The version string, as shown by [ShowVersion], or the empty string if no version is specified.
+	InfoLines              []string       // The info lines, with any ":version:" line replaced by the version string, and any metadata line (see [UsageParams.Metadata]) replaced by the metadata, or removed if that is not specified.
+	FlagsAndOptionsString  string         // The flags/options string of the usage line (without leading space), or the empty string if none is to be shown.
+	ValuesString           string         // The values string of the usage line (without leading space).
+	HasSpecifications      bool           // Indicates whether any specifications are given.
+	SkipBlanksBetweenLines bool           // Indicates whether [SkipBlanksBetweenLines] is specified.
+	Sections               []UsageSection // The sections, each containing its flag/option specifications.
+	UsageHeading           string         // The (localised) usage heading, e.g. "USAGE:".
+	FlagsAndOptionsHeading string         // The (localised) flags/options heading, e.g. "flags/options:".
+	WhereValueOneOf        string         // The (localised) introduction to the value set of an option, e.g. "where <value> one of:".
+}
+
+// A section in [UsageData]. Flags/options preceding the first section are
+// placed in a section whose name is the empty string.
+type UsageSection struct {
+	Name           string               // The section name.
+	Specifications []UsageSpecification // The flag/option specifications in the section.
+}
+
+// A flag/option specification in [UsageData].
+type UsageSpecification struct {
+	Type         ArgType           // The type, either [FlagType] or [OptionType].
+	IsFlag       bool              // Indicates whether the specification is of a flag.
+	IsOption     bool              // Indicates whether the specification is of an option.
+	Name         string            // The name, e.g. "--verbosity".
+	Aliases      []string          // The aliases, e.g. "-V".
+	Help         string            // The help string.
+	Values       []string          // The value set of an option.
+	ValueAliases []UsageValueAlias // The option-value alias specifications of an option, e.g. "-c" for "--verbosity=chatty".
+}
+
+// An option-value alias in [UsageSpecification].
+type UsageValueAlias struct {
+	Name    string   // The name of the specification, e.g. "--verbosity=chatty".
+	Value   string   // The value, e.g. "chatty".
+	Aliases []string // The aliases, e.g. "-c".
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// The text of a usage template (see [UsageParams.Template]) that produces
+// the same output as [ShowUsage] does when no template is specified.
+const DefaultUsageTemplate = `{{range .InfoLines}}{{.}}
+{{end}}{{.UsageHeading}} {{.ProgramName}}{{with .FlagsAndOptionsString}} {{.}}{{end}}{{with .ValuesString}} {{.}}{{end}}
+{{if .HasSpecifications}}
+{{.FlagsAndOptionsHeading}}
+{{if not .SkipBlanksBetweenLines}}
+{{end}}{{range .Sections}}{{if .Name}}{{if $.SkipBlanksBetweenLines}}
+{{end}}	{{.Name}}
+
+{{end}}{{range .Specifications}}{{if .IsOption}}{{range .ValueAliases}}{{$name := .Name}}{{with .Aliases}}	{{index . 0}} {{$name}}
+{{end}}{{end}}{{range .Aliases}}	{{.}} <value>
+{{end}}	{{.Name}}=<value>
+{{else}}{{range .Aliases}}	{{.}}
+{{end}}	{{.Name}}
+{{end}}{{with .Help}}		{{.}}
+{{end}}{{with .Values}}		{{$.WhereValueOneOf}}
+{{range .}}			{{.}}
+{{end}}{{end}}{{if not $.SkipBlanksBetweenLines}}
+{{end}}{{end}}{{end}}{{end}}`
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Builds the usage data of the given specifications, which are as obtained
+// from usage_specifications().
+func build_usage_data(specifications []Specification, params UsageParams) (data UsageData, err error) {
+
+	data.ProgramName = get_program_name(params)
+
+	data.UsageHeading = usage_message(params, Message_Usage)
+	data.FlagsAndOptionsHeading = usage_message(params, Message_FlagsAndOptions)
+	data.WhereValueOneOf = substitute_placeholder(usage_message(params, Message_WhereValueOneOf), "<value>")
+
+	if nil != params.Version {
+
+		if data.Version, err = generate_version_string(params, "ShowUsage"); err != nil {
+
+			return
+		}
+	}
+
+	if data.InfoLines, err = expand_info_lines(params, "ShowUsage"); err != nil {
+
+		return
+	}
+
+	data.FlagsAndOptionsString = params.FlagsAndOptionsString
+	if "" == data.FlagsAndOptionsString && 0 != len(specifications) {
+
+		data.FlagsAndOptionsString = usage_message(params, Message_FlagsAndOptionsPlaceholder)
+	}
+	if "" == strings.TrimSpace(data.FlagsAndOptionsString) {
+
+		data.FlagsAndOptionsString = ""
+	}
+
+	data.ValuesString = params.ValuesString
+	data.HasSpecifications = 0 != len(specifications)
+	data.SkipBlanksBetweenLines = 0 != (SkipBlanksBetweenLines & params.UsageFlags)
+
+	groups, value_aliases := group_specifications(specifications)
+
+	for _, group := range groups {
+
+		section := UsageSection{Name: group.name}
+
+		for _, a := range group.specifications {
+
+			us := UsageSpecification{
+
+				Type:     a.Type,
+				IsFlag:   FlagType == a.Type,
+				IsOption: OptionType == a.Type,
+				Name:     a.Name,
+				Aliases:  a.Aliases,
+				Help:     a.Help,
+				Values:   a.ValueSet,
+			}
+
+			if us.IsOption {
+
+				for _, c := range value_aliases[a.Name] {
+
+					us.ValueAliases = append(us.ValueAliases, UsageValueAlias{
+
+						Name:    c.Name,
+						Value:   c.Name[len(a.Name)+1:],
+						Aliases: c.Aliases,
+					})
+				}
+			}
+
+			section.Specifications = append(section.Specifications, us)
+		}
+
+		data.Sections = append(data.Sections, section)
+	}
+
+	return
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
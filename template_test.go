@@ -0,0 +1,166 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func show_usage_to_string_(t *testing.T, specifications []clasp.Specification, params clasp.UsageParams) string {
+
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	params.Stream = buf
+	params.UsageFlags |= clasp.DontCallExit
+
+	_, err := clasp.ShowUsage(specifications, params)
+
+	require.Nil(t, err)
+
+	return buf.String()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_DefaultUsageTemplate_matches_ShowUsage(t *testing.T) {
+
+	default_template := template.Must(template.New("usage").Parse(clasp.DefaultUsageTemplate))
+
+	specification_sets := [][]clasp.Specification{
+
+		nil,
+		{
+			clasp.HelpFlag(),
+		},
+		{
+			clasp.Flag("--debug").SetHelp("Debug mode"),
+
+			clasp.Section("behaviour:"),
+			clasp.Flag("--verbose").SetAliases("-v", "-w").SetHelp("Makes output verbose"),
+			clasp.Option("--verbosity").SetAlias("-V").SetHelp("Specifies the verbosity").SetValues("terse", "quiet", "chatty"),
+			clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+			clasp.Flag("--verbosity=terse").SetAlias("-t"),
+			clasp.Option("--level"),
+
+			clasp.Section("standard:"),
+			clasp.HelpFlag(),
+			clasp.VersionFlag(),
+		},
+	}
+
+	params_set := []clasp.UsageParams{
+
+		{
+			ProgramName: "myprog",
+		},
+		{
+			ProgramName:   "myprog",
+			UsageFlags:    clasp.SkipBlanksBetweenLines,
+			ValuesString:  "<path>",
+			Version:       []int{1, 2, 3},
+			VersionPrefix: "v",
+			InfoLines:     []string{"CLASP.Go Test Suite", "", ":version:", ""},
+		},
+		{
+			ProgramName:           "myprog",
+			FlagsAndOptionsString: " ",
+			ValuesString:          "<src> <dest>",
+		},
+		{
+			ProgramName:           "myprog",
+			FlagsAndOptionsString: "[options]",
+		},
+		{
+			ProgramName: "myprog",
+			Locale:      "de_DE",
+		},
+		{
+			ProgramName: "myprog",
+			UsageFlags:  clasp.SkipBlanksBetweenLines,
+			Locale:      "ja",
+		},
+	}
+
+	for i, specifications := range specification_sets {
+
+		for j, params := range params_set {
+
+			expected := show_usage_to_string_(t, specifications, params)
+
+			params.Template = default_template
+
+			actual := show_usage_to_string_(t, specifications, params)
+
+			require.Equal(t, expected, actual, "specification set %d, params %d", i, j)
+		}
+	}
+}
+
+func Test_ShowUsage_with_custom_Template(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Flag("--verbose").SetAlias("-v").SetHelp("Makes output verbose"),
+		clasp.Option("--verbosity").SetHelp("Specifies the verbosity").SetValues("terse", "chatty"),
+		clasp.Flag("--verbosity=chatty").SetAlias("-c"),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+	}
+
+	house_style := template.Must(template.New("usage").Parse(`Usage: {{.ProgramName}} {{.FlagsAndOptionsString}} ({{.Version}})
+{{range .Sections}}
+{{.Name}}
+{{range .Specifications}}  {{.Name}}{{range .Aliases}}|{{.}}{{end}}: {{.Help}}
+{{range .ValueAliases}}    {{.Value}}{{range .Aliases}} ({{.}}){{end}}
+{{end}}{{end}}{{end}}`))
+
+	expected := `Usage: myprog [ ... flags and options ... ] (myprog 1.0)
+
+behaviour:
+  --verbose|-v: Makes output verbose
+  --verbosity: Specifies the verbosity
+    chatty (-c)
+
+standard:
+  --help: Shows this help and exits
+`
+
+	actual := show_usage_to_string_(t, specifications, clasp.UsageParams{
+
+		ProgramName: "myprog",
+		Version:     "1.0",
+		Template:    house_style,
+	})
+
+	stegol.CheckStringEqual(t, expected, actual)
+}
+
+func Test_ShowUsage_with_failing_Template(t *testing.T) {
+
+	failing := template.Must(template.New("usage").Parse(`{{.NoSuchField}}`))
+
+	buf := new(bytes.Buffer)
+
+	_, err := clasp.ShowUsage(nil, clasp.UsageParams{
+
+		Stream:     buf,
+		UsageFlags: clasp.DontCallExit,
+		Template:   failing,
+	})
+
+	require.NotNil(t, err)
+}
@@ -5,7 +5,7 @@
 
 /*
  * Created: 4th September 2015
- * Updated: 28th February 2025
+ * Updated: 19th October 2026
  */
 
 package clasp
@@ -15,8 +15,9 @@ import (
 	"io"
 	"os"
 	"path"
-	"reflect"
+	"slices"
 	"strings"
+	"text/template"
 )
 
 /* /////////////////////////////////////////////////////////////////////////
@@ -33,11 +34,19 @@ type Exiter interface {
 
 // Defines options for usage (see [ShowUsage]).
 type UsageParams struct {
-	Stream        io.Writer
-	ProgramName   string
-	UsageFlags    UsageFlag
-	ExitCode      int
-	Exiter        Exiter
+	Stream      io.Writer
+	ProgramName string
+	UsageFlags  UsageFlag
+	ExitCode    int
+	Exiter      Exiter
+	// The version, which may be: a string, e.g. "1.2.3"; a []string,
+	// []int, or []uint16 of the version parts; a uint64 packed in the
+	// manner of [Version]; a [fmt.Stringer]; a structure (or pointer to
+	// one) with integral fields Major, Minor, and Patch, and either an
+	// integral AB field, interpreted as by ver2go, or optional string
+	// fields PreRelease and Build, interpreted as by semantic versioning;
+	// or [VersionFromBuildInfo]. If of any other type, [ShowUsage] and
+	// [ShowVersion] return an error.
 	Version       interface{}
 	VersionPrefix string
 	InfoLines     []string
@@ -46,11 +55,58 @@ type UsageParams struct {
 	// any specifications are specified; if a whitespace-only string is specified,
 	// then no flags/options element is presented
 	FlagsAndOptionsString string
+	// The width, in columns, to which usage is laid out when
+	// [Usage_TwoColumnLayout] is specified. If 0, the value of the
+	// environment variable `COLUMNS` is used, if valid, or otherwise 80.
+	Width int
+	// Function used to look up environment variables. If `nil`,
+	// [os.LookupEnv] is used.
+	LookupEnv func(key string) (string, bool)
+	// If specified, the template used by [ShowUsage] to render the usage,
+	// being given a [UsageData]. See [DefaultUsageTemplate] for the
+	// template that reproduces the default usage.
+	Template *template.Template
+	// The colour theme used when colour is specified (see [Usage_Colour]
+	// and [Usage_ColourAuto]). If `nil`, [DefaultColourTheme] is used.
+	Theme *ColourTheme
+	// The catalogue of messages used in preference to the built-in
+	// messages for [UsageParams.Locale]. May be `nil`.
+	Messages MessageCatalogue
+	// The locale - e.g. "de_DE" - in which usage is shown, which selects
+	// the built-in messages (see [BuiltInMessageCatalogue]) and localised
+	// help (see [Specification.SetLocalisedHelp]). If empty, usage is
+	// shown in English; see [LocaleFromEnvironment] for obtaining the
+	// locale from the environment.
+	Locale string
+	// The application metadata shown by [ShowVersion] in the long and JSON
+	// formats, and by [ShowUsage] for the info lines ":copyright:",
+	// ":licence:", ":authors:", ":build-date:", ":go-version:", ":commit:",
+	// and ":commit-date:", each of which is omitted if the corresponding
+	// item is not specified. May be `nil`.
+	Metadata *ApplicationMetadata
+	// The format in which [ShowVersion] shows the version. See
+	// [Arguments.LookupVersionFormat] for obtaining the format from the
+	// command-line.
+	VersionFormat VersionFormat
+	// If specified, [ShowUsage] shows only the section whose name - with or
+	// without its trailing ':', and ignoring case - or the flag/option
+	// whose name or alias - with or without its leading hyphens - matches
+	// the topic. If none matches, [ShowUsage] returns a [NoHelpTopicError],
+	// which includes any suggested topics, without writing to the stream
+	// or calling the exiter. See [Arguments.LookupHelp] for obtaining the
+	// topic from the command-line, e.g. "--help=output".
+	HelpTopic string
 }
 
 func (params UsageParams) String() string {
 
-	return fmt.Sprintf("<%T{ Stream=%v, ProgramName=%q, UsageFlags=0x%x, ExitCode=%d, Exiter=%v, Version=%v, VersionPrefix=%q, InfoLines=%v, ValuesString=%q, FlagsAndOptionsString=%q }>", params, params.Stream, params.ProgramName, params.UsageFlags, params.ExitCode, params.Exiter, params.Version, params.VersionPrefix, params.InfoLines, params.ValuesString, params.FlagsAndOptionsString)
+	template_name := "<nil>"
+	if nil != params.Template {
+
+		template_name = fmt.Sprintf("%q", params.Template.Name())
+	}
+
+	return fmt.Sprintf("<%T{ Stream=%v, ProgramName=%q, UsageFlags=0x%x, ExitCode=%d, Exiter=%v, Version=%v, VersionPrefix=%q, InfoLines=%v, ValuesString=%q, FlagsAndOptionsString=%q, Width=%d, Template=%s, Messages=%v, Locale=%q }>", params, params.Stream, params.ProgramName, params.UsageFlags, params.ExitCode, params.Exiter, params.Version, params.VersionPrefix, params.InfoLines, params.ValuesString, params.FlagsAndOptionsString, params.Width, template_name, params.Messages, params.Locale)
 }
 
 /* /////////////////////////////////////////////////////////////////////////
@@ -67,6 +123,12 @@ const (
 	SkipBlanksBetweenLines UsageFlag = 1 << iota // T.B.C.
 	DontCallExit                                 // T.B.C.
 	DontCallExitIfZero                           // T.B.C.
+	Usage_TwoColumnLayout                        // Causes flags/options to be listed in two columns - names and aliases, and then (word-wrapped) help - in the style of GNU tools.
+	Usage_Colour                                 // Causes output to be colourised, according to [UsageParams.Theme].
+	Usage_ColourAuto                             // Causes output to be colourised, according to [UsageParams.Theme], if the stream is a terminal (see [TerminalStream]), unless overridden by the environment variables `NO_COLOR` (which suppresses colour) and `CLICOLOR_FORCE` (which forces colour).
+	Usage_ShowHelpHint                           // Causes [Arguments.VerifyAllFlagsAndOptionsUsed] to follow its report with a hint to use the help flag for usage.
+	Usage_ShowDiagnostics                        // Causes [Arguments.VerifyAllFlagsAndOptionsUsed] to report each unused argument as a diagnostic that indicates it within the command line (see [FormatDiagnosticAt]).
+	Usage_ShowAll                                // Causes deprecated and advanced specifications (see [Specification.SetDeprecated] and [Specification.SetAdvanced]) to be included in usage and generated documentation, as for a "--help-all" flag; hidden specifications (see [Specification.SetHidden]) are never included.
 )
 
 /* /////////////////////////////////////////////////////////////////////////
@@ -125,46 +187,173 @@ func get_program_name(params UsageParams) string {
 	return program_name
 }
 
-func generate_version_string(params UsageParams, apiFunctionName string) string {
+func generate_version_string(params UsageParams, apiFunctionName string) (string, error) {
 
-	program_name := get_program_name(params)
-	version_prefix := params.VersionPrefix
+	program_name, version, err := generate_version_parts(params, apiFunctionName)
+	if err != nil {
 
-	var version string
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s", program_name, version), nil
+}
+
+func generate_version_parts(params UsageParams, apiFunctionName string) (program_name string, prefixed_version string, err error) {
+
+	program_name = get_program_name(params)
+
+	version, err := version_value_string(params.Version, apiFunctionName)
+	if err != nil {
+
+		return "", "", err
+	}
+
+	return program_name, params.VersionPrefix + version, nil
+}
+
+// A group of flag/option specifications, introduced by a section (or not,
+// in the case of those preceding the first section), as presented in
+// generated documentation.
+type specification_group struct {
+	name           string
+	specifications []Specification
+}
+
+// Splits the given specifications into groups, one for each section and,
+// if there are any specifications preceding the first section, an unnamed
+// one at the start, and separately collects, keyed by the name of their
+// option, the option-value alias specifications (e.g.
+// `--verbosity=chatty`).
+func group_specifications(specifications []Specification) (groups []specification_group, value_aliases map[string][]Specification) {
+
+	value_aliases = make(map[string][]Specification)
+
+	for _, a := range specifications {
+
+		switch a.Type {
 
-	switch v := params.Version.(type) {
+		case SectionType:
 
-	case string:
+			groups = append(groups, specification_group{name: a.Name})
+		case FlagType, OptionType:
 
-		version = v
-	case []string:
+			if ix_eq := strings.Index(a.Name, "="); ix_eq >= 0 {
 
-		version = strings.Join(v, ".")
-	case []int:
-		as := make([]string, len(v))
+				name := a.Name[0:ix_eq]
 
-		for i, n := range v {
+				value_aliases[name] = append(value_aliases[name], a)
 
-			as[i] = fmt.Sprintf("%v", n)
+				continue
+			}
+
+			if 0 == len(groups) {
+
+				groups = append(groups, specification_group{})
+			}
+
+			groups[len(groups)-1].specifications = append(groups[len(groups)-1].specifications, a)
 		}
+	}
 
-		version = strings.Join(as, ".")
-	case []uint16:
+	return
+}
+
+// Obtains those of the given option-value alias specifications of the
+// given option whose values are not in its value set, and so are not
+// presented with that, which are instead presented as names of the option
+// in the form "-f --mode=fast".
+func value_aliases_outside_value_set(a Specification, value_aliases []Specification) (r []Specification) {
 
-		as := make([]string, len(v))
+	for _, c := range value_aliases {
 
-		for i, n := range v {
+		if !slices.Contains(a.ValueSet, c.Name[len(a.Name)+1:]) {
 
-			as[i] = fmt.Sprintf("%v", n)
+			r = append(r, c)
 		}
+	}
 
-		version = strings.Join(as, ".")
-	default:
+	return
+}
+
+func write_tabbed_specifications(params UsageParams, painter usage_painter, specifications []Specification) {
+
+	voas := make(map[string][]Specification)
+	pure := make([]Specification, 0)
+
+	for _, a := range specifications {
 
-		panic(fmt.Sprintf("%v() called with UsageParams.Version of an invalid type %T, but must be instance of string, []string, []int, []uint16", v, reflect.TypeOf(v)))
+		ix_eq := strings.Index(a.Name, "=")
+
+		if ix_eq < 0 {
+
+			pure = append(pure, a)
+		} else {
+
+			name := a.Name[0:ix_eq]
+
+			if _, ok := voas[name]; !ok {
+
+				voas[name] = make([]Specification, 0)
+			}
+
+			voas[name] = append(voas[name], a)
+		}
 	}
 
-	return fmt.Sprintf("%s %s%s", program_name, version_prefix, version)
+	for _, a := range pure {
+
+		switch a.Type {
+
+		case FlagType:
+
+			for _, b := range a.Aliases {
+
+				fmt.Fprintf(params.Stream, "\t%v\n", painter.name(b))
+			}
+			fmt.Fprintf(params.Stream, "\t%v\n", painter.name(a.Name))
+
+		case OptionType:
+
+			for _, c := range voas[a.Name] {
+
+				fmt.Fprintf(params.Stream, "\t%v %v\n", painter.name(c.Aliases[0]), painter.name(c.Name))
+			}
+			for _, b := range a.Aliases {
+
+				fmt.Fprintf(params.Stream, "\t%v %v\n", painter.name(b), painter.placeholder("<value>"))
+			}
+			fmt.Fprintf(params.Stream, "\t%v=%v\n", painter.name(a.Name), painter.placeholder("<value>"))
+
+		case SectionType:
+
+			if 0 != (SkipBlanksBetweenLines & params.UsageFlags) {
+
+				fmt.Fprintf(params.Stream, "\n")
+			}
+			fmt.Fprintf(params.Stream, "\t%v\n\n", painter.header(a.Name))
+
+			continue
+		}
+
+		if 0 != len(a.Help) {
+
+			fmt.Fprintf(params.Stream, "\t\t%v\n", a.Help)
+		}
+
+		if 0 != len(a.ValueSet) {
+
+			fmt.Fprintln(params.Stream, "\t\t"+substitute_placeholder(usage_message(params, Message_WhereValueOneOf), painter.placeholder("<value>")))
+			for j := 0; j != len(a.ValueSet); j++ {
+
+				fmt.Fprintf(params.Stream, "\t\t\t%v\n", painter.value(a.ValueSet[j]))
+			}
+		}
+
+		if 0 == (SkipBlanksBetweenLines & params.UsageFlags) {
+
+			fmt.Fprintf(params.Stream, "\n")
+		}
+	}
 }
 
 /* /////////////////////////////////////////////////////////////////////////
@@ -206,11 +395,38 @@ func ShowUsage(specifications []Specification, params UsageParams) (rc int, err
 		}
 	}
 
+	if specifications, err = usage_specifications(specifications, params); err != nil {
+
+		return params.ExitCode, err
+	}
+
+	if nil != params.Template {
+
+		data, err := build_usage_data(specifications, params)
+		if err != nil {
+
+			return params.ExitCode, err
+		}
+
+		if err = params.Template.Execute(params.Stream, data); err != nil {
+
+			return params.ExitCode, err
+		}
+
+		if should_call_Exit(params) {
+
+			exiter.Exit(params.ExitCode)
+		}
+
+		return params.ExitCode, nil
+	}
+
+	painter := new_usage_painter(params)
 	program_name := get_program_name(params)
 
 	if "" == params.FlagsAndOptionsString && 0 != len(specifications) {
 
-		params.FlagsAndOptionsString = "[ ... flags and options ... ]"
+		params.FlagsAndOptionsString = usage_message(params, Message_FlagsAndOptionsPlaceholder)
 	}
 
 	if "" != strings.TrimSpace(params.FlagsAndOptionsString) {
@@ -226,106 +442,55 @@ func ShowUsage(specifications []Specification, params UsageParams) (rc int, err
 		params.ValuesString = " " + params.ValuesString
 	}
 
+	var version string
+
 	for _, info_line := range params.InfoLines {
 
 		if ":version:" == info_line {
 
-			version := generate_version_string(params, "ShowUsage")
+			if _, version, err = generate_version_parts(params, "ShowUsage"); err != nil {
 
-			fmt.Fprintf(params.Stream, "%s\n", version)
-		} else {
+				return params.ExitCode, err
+			}
 
-			fmt.Fprintf(params.Stream, "%s\n", info_line)
+			break
 		}
 	}
 
-	fmt.Fprintf(params.Stream, "USAGE: %s%s%s\n", program_name, params.FlagsAndOptionsString, params.ValuesString)
-
-	if 0 != len(specifications) {
-
-		fmt.Fprintf(params.Stream, "\n")
-		fmt.Fprintf(params.Stream, "flags/options:\n")
-		if 0 == (SkipBlanksBetweenLines & params.UsageFlags) {
-
-			fmt.Fprintf(params.Stream, "\n")
-		}
-
-		voas := make(map[string][]Specification)
-		pure := make([]Specification, 0)
-
-		for _, a := range specifications {
-
-			ix_eq := strings.Index(a.Name, "=")
-
-			if ix_eq < 0 {
-
-				pure = append(pure, a)
-			} else {
+	for _, info_line := range params.InfoLines {
 
-				name := a.Name[0:ix_eq]
+		if ":version:" == info_line {
 
-				if _, ok := voas[name]; !ok {
+			fmt.Fprintf(params.Stream, "%s %s\n", painter.name(program_name), painter.value(version))
+		} else if line, is_token := metadata_line(params, info_line); is_token {
 
-					voas[name] = make([]Specification, 0)
-				}
+			if "" != line {
 
-				voas[name] = append(voas[name], a)
+				fmt.Fprintf(params.Stream, "%s\n", line)
 			}
-		}
-
-		for _, a := range pure {
-
-			switch a.Type {
-
-			case FlagType:
-
-				for _, b := range a.Aliases {
-
-					fmt.Fprintf(params.Stream, "\t%v\n", b)
-				}
-				fmt.Fprintf(params.Stream, "\t%v\n", a.Name)
-
-			case OptionType:
-
-				for _, c := range voas[a.Name] {
-
-					fmt.Fprintf(params.Stream, "\t%v %v\n", c.Aliases[0], c.Name)
-				}
-				for _, b := range a.Aliases {
-
-					fmt.Fprintf(params.Stream, "\t%v <value>\n", b)
-				}
-				fmt.Fprintf(params.Stream, "\t%v=<value>\n", a.Name)
-
-			case SectionType:
-
-				if 0 != (SkipBlanksBetweenLines & params.UsageFlags) {
-
-					fmt.Fprintf(params.Stream, "\n")
-				}
-				fmt.Fprintf(params.Stream, "\t%v\n\n", a.Name)
+		} else {
 
-				continue
-			}
+			fmt.Fprintf(params.Stream, "%s\n", info_line)
+		}
+	}
 
-			if 0 != len(a.Help) {
+	fmt.Fprintf(params.Stream, "%s %s%s%s\n", painter.header(usage_message(params, Message_Usage)), painter.name(program_name), params.FlagsAndOptionsString, params.ValuesString)
 
-				fmt.Fprintf(params.Stream, "\t\t%v\n", a.Help)
-			}
+	if 0 != len(specifications) {
 
-			if 0 != len(a.ValueSet) {
+		fmt.Fprintf(params.Stream, "\n")
+		fmt.Fprintf(params.Stream, "%s\n", painter.header(usage_message(params, Message_FlagsAndOptions)))
+		if 0 == (SkipBlanksBetweenLines & params.UsageFlags) {
 
-				fmt.Fprintf(params.Stream, "\t\twhere <value> one of:\n")
-				for j := 0; j != len(a.ValueSet); j++ {
+			fmt.Fprintf(params.Stream, "\n")
+		}
 
-					fmt.Fprintf(params.Stream, "\t\t\t%v\n", a.ValueSet[j])
-				}
-			}
+		if 0 != (Usage_TwoColumnLayout & params.UsageFlags) {
 
-			if 0 == (SkipBlanksBetweenLines & params.UsageFlags) {
+			write_two_column_specifications(params, painter, specifications)
+		} else {
 
-				fmt.Fprintf(params.Stream, "\n")
-			}
+			write_tabbed_specifications(params, painter, specifications)
 		}
 	}
 
@@ -358,9 +523,42 @@ func ShowVersion(specifications []Specification, params UsageParams) (rc int, er
 		exiter = new(default_exiter)
 	}
 
-	version := generate_version_string(params, "ShowVersion")
+	painter := new_usage_painter(params)
+	program_name, version, err := generate_version_parts(params, "ShowVersion")
+	if err != nil {
+
+		return params.ExitCode, err
+	}
+
+	switch params.VersionFormat {
+
+	case VersionFormat_Short:
+
+		fmt.Fprintf(params.Stream, "%s %s\n", painter.name(program_name), painter.value(version))
+	case VersionFormat_Long:
+
+		fmt.Fprintf(params.Stream, "%s %s\n", painter.name(program_name), painter.value(version))
+
+		for _, token := range metadata_info_line_tokens {
 
-	fmt.Fprintf(params.Stream, "%s\n", version)
+			if line, _ := metadata_line(params, token); "" != line {
+
+				fmt.Fprintf(params.Stream, "%s\n", line)
+			}
+		}
+	case VersionFormat_JSON:
+
+		s, err := version_json_string(program_name, strings.TrimPrefix(version, params.VersionPrefix), params.Metadata)
+		if err != nil {
+
+			return params.ExitCode, err
+		}
+
+		fmt.Fprintf(params.Stream, "%s\n", s)
+	default:
+
+		return params.ExitCode, fmt.Errorf("ShowVersion() called with an invalid UsageParams.VersionFormat %v", params.VersionFormat)
+	}
 
 	if should_call_Exit(params) {
 
@@ -0,0 +1,203 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"sync"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// The use state of an argument, which is shared by all copies of it, and
+// whose members are accessed only with mx locked.
+type argument_use struct {
+	mx      sync.Mutex
+	used    bool
+	claimed bool
+	owner   string
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func peek_argument_named(arguments []*Argument, name string) (*Argument, bool) {
+
+	for _, arg := range arguments {
+
+		if name == arg.ResolvedName {
+
+			return arg, true
+		}
+	}
+
+	return nil, false
+}
+
+// Indicates whether the use state - which is not yet allocated, and so
+// unused, if nil - is used.
+func (use *argument_use) is_used() bool {
+
+	if nil == use {
+
+		return false
+	}
+
+	use.mx.Lock()
+	defer use.mx.Unlock()
+
+	return use.used
+}
+
+func (use *argument_use) mark_used() {
+
+	use.mx.Lock()
+	defer use.mx.Unlock()
+
+	use.used = true
+}
+
+// Obtains the argument's use state, allocating it if necessary.
+//
+// NOTE: The allocation is not synchronised, which is why [Parse] allocates
+// the use state of each argument before it is returned.
+func (arg *Argument) use_state() *argument_use {
+
+	if nil == arg.use_ {
+
+		arg.use_ = new(argument_use)
+	}
+
+	return arg.use_
+}
+
+func claim_argument_named(arguments []*Argument, name string, owner string) (*Argument, bool) {
+
+	for _, arg := range arguments {
+
+		if name == arg.ResolvedName {
+
+			if arg.Claim(owner) {
+
+				return arg, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Indicates whether the argument has been marked used, either by
+// [Argument.Use] - including by lookups such as [Arguments.LookupFlag] -
+// or by [Argument.Claim].
+func (arg *Argument) IsUsed() bool {
+
+	return arg.use_.is_used()
+}
+
+// Marks the argument as unused, reversing [Argument.Use] and
+// [Argument.Claim], such that it will be obtained in a call to
+// [Arguments.GetUnusedFlags] / [Arguments.GetUnusedOptions] /
+// [Arguments.GetUnusedFlagsAndOptions] / [Arguments.GetUnusedValues]
+// (subject to its type), and may be claimed by another owner.
+func (arg *Argument) Unuse() {
+
+	if nil == arg.use_ {
+
+		return
+	}
+
+	arg.use_.mx.Lock()
+	defer arg.use_.mx.Unlock()
+
+	arg.use_.used = false
+	arg.use_.claimed = false
+	arg.use_.owner = ""
+}
+
+// Marks the argument as used (see [Argument.Use]) on behalf of the given
+// owner - e.g. the name of the component that recognises it - and
+// indicates whether the argument is claimed by that owner. An argument
+// may be claimed by only one owner: the first to claim it; claims by
+// other owners fail. An argument that has been marked used but not
+// claimed may still be claimed.
+//
+// NOTE: This may be called concurrently from multiple goroutines, such
+// that each of several components sharing the same [Arguments] may claim
+// its arguments without further synchronisation.
+func (arg *Argument) Claim(owner string) bool {
+
+	use := arg.use_state()
+
+	use.mx.Lock()
+	defer use.mx.Unlock()
+
+	if use.claimed {
+
+		return owner == use.owner
+	}
+
+	use.used = true
+	use.claimed = true
+	use.owner = owner
+
+	return true
+}
+
+// Obtains the owner that has claimed the argument (see [Argument.Claim]),
+// if any.
+func (arg *Argument) Owner() (owner string, claimed bool) {
+
+	if nil == arg.use_ {
+
+		return
+	}
+
+	arg.use_.mx.Lock()
+	defer arg.use_.mx.Unlock()
+
+	return arg.use_.owner, arg.use_.claimed
+}
+
+// Marks all arguments as unused (see [Argument.Unuse]), including those
+// marked used during parsing (see
+// [Parse_DontMarkUsedDuringParseWhenMatchingBitFlags]).
+func (args *Arguments) ResetUse() {
+
+	for _, arg := range args.Arguments {
+
+		arg.Unuse()
+	}
+}
+
+// Claims (see [Argument.Claim]), on behalf of the given owner, the first
+// flag - specified either as `string` or [Specification] - that is not
+// claimed by another owner, and obtains it, if any.
+func (args *Arguments) ClaimFlag(id interface{}, owner string) (*Argument, bool) {
+
+	return claim_argument_named(args.Flags, argument_name_from_id(id, "ClaimFlag", FlagType), owner)
+}
+
+// Claims (see [Argument.Claim]), on behalf of the given owner, the first
+// option - specified either as `string` or [Specification] - that is not
+// claimed by another owner, and obtains it, if any.
+func (args *Arguments) ClaimOption(id interface{}, owner string) (*Argument, bool) {
+
+	return claim_argument_named(args.Options, argument_name_from_id(id, "ClaimOption", OptionType), owner)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,376 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+func use_arguments() *clasp.Arguments {
+
+	return clasp.Parse([]string{"myprog", "-v", "--debug", "--input=in.txt", "--output=out.txt", "--level=3", "--unknown", "value"}, clasp.ParseParams{
+
+		Specifications: []clasp.Specification{
+
+			clasp.Flag("--verbose").SetAlias("-v"),
+			clasp.Flag("--debug"),
+			clasp.Option("--input"),
+			clasp.Option("--output"),
+			clasp.Option("--level"),
+		},
+	})
+}
+
+// Runs fn concurrently in n goroutines, passing each its index.
+func run_concurrently(n int, fn func(i int)) {
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+
+	for i := 0; i != n; i++ {
+
+		wg.Add(1)
+
+		go func(i int) {
+
+			defer wg.Done()
+
+			<-start
+
+			fn(i)
+		}(i)
+	}
+
+	close(start)
+
+	wg.Wait()
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_Claim(t *testing.T) {
+
+	args := use_arguments()
+
+	arg, found := args.LookupOption("--input")
+
+	require.True(t, found)
+
+	// marked used by the lookup, but not claimed
+
+	owner, claimed := arg.Owner()
+
+	require.False(t, claimed)
+	require.Equal(t, "", owner)
+
+	require.True(t, arg.Claim("reader"))
+	require.True(t, arg.Claim("reader"))
+	require.False(t, arg.Claim("writer"))
+
+	owner, claimed = arg.Owner()
+
+	require.True(t, claimed)
+	require.Equal(t, "reader", owner)
+
+	// Use() does not affect a claim
+
+	arg.Use()
+
+	owner, _ = arg.Owner()
+
+	require.Equal(t, "reader", owner)
+}
+
+func Test_ClaimFlag_and_ClaimOption(t *testing.T) {
+
+	args := use_arguments()
+
+	arg, claimed := args.ClaimFlag("--verbose", "logging")
+
+	require.True(t, claimed)
+	require.Equal(t, "-v", arg.GivenName)
+
+	_, claimed = args.ClaimFlag(clasp.Flag("--verbose"), "other")
+
+	require.False(t, claimed)
+
+	_, claimed = args.ClaimOption("--output", "writer")
+
+	require.True(t, claimed)
+
+	_, claimed = args.ClaimOption("--not-there", "writer")
+
+	require.False(t, claimed)
+
+	require.Panics(t, func() { args.ClaimOption(clasp.Flag("--debug"), "writer") })
+
+	var unused []string
+
+	for _, arg := range args.GetUnusedFlagsAndOptions() {
+
+		unused = append(unused, arg.Str())
+	}
+
+	require.Equal(t, []string{"--debug", "--input=in.txt", "--level=3", "--unknown"}, unused)
+}
+
+func Test_concurrent_lookups(t *testing.T) {
+
+	const N = 32
+
+	args := use_arguments()
+
+	run_concurrently(N, func(i int) {
+
+		switch i % 4 {
+
+		case 0:
+
+			args.FlagIsSpecified("--verbose")
+		case 1:
+
+			args.LookupFlag("--debug")
+		case 2:
+
+			args.LookupOption("--input")
+		case 3:
+
+			for range args.OptionsNamed("--output") {
+			}
+		}
+
+		args.GetUnusedFlagsAndOptions()
+	})
+
+	unused := args.GetUnusedFlagsAndOptions()
+
+	require.Equal(t, 2, len(unused))
+	require.Equal(t, "--level", unused[0].ResolvedName)
+	require.Equal(t, "--unknown", unused[1].ResolvedName)
+}
+
+func Test_concurrent_claims(t *testing.T) {
+
+	const N = 64
+
+	args := use_arguments()
+
+	var numClaimed [3]atomic.Int32
+
+	names := []string{"--input", "--output", "--level"}
+
+	run_concurrently(N, func(i int) {
+
+		owner := fmt.Sprintf("component-%d", i)
+
+		for j, name := range names {
+
+			if _, claimed := args.ClaimOption(name, owner); claimed {
+
+				numClaimed[j].Add(1)
+			}
+		}
+	})
+
+	// each option is claimed by exactly one component
+
+	for j, name := range names {
+
+		require.Equal(t, int32(1), numClaimed[j].Load(), "for '%s'", name)
+
+		arg, _ := args.LookupOption(name)
+
+		_, claimed := arg.Owner()
+
+		require.True(t, claimed)
+	}
+}
+
+func Test_concurrent_lookups_and_formatting(t *testing.T) {
+
+	const N = 32
+
+	args := use_arguments()
+
+	run_concurrently(N, func(i int) {
+
+		switch i % 4 {
+
+		case 0:
+
+			args.LookupFlag("--verbose")
+		case 1:
+
+			args.LookupOption("--input")
+		case 2:
+
+			_ = args.Flags[0].Str()
+			_ = args.Options[0].Str()
+		case 3:
+
+			_ = args.Flags[0].String()
+			_ = args.Options[0].String()
+		}
+	})
+
+	require.Contains(t, args.Flags[0].String(), "used=true")
+	require.Contains(t, args.Options[0].String(), "used=true")
+}
+
+func Test_Argument_value_is_Stringer(t *testing.T) {
+
+	args := use_arguments()
+
+	args.LookupFlag("--verbose")
+
+	var stringer fmt.Stringer = *args.Flags[0]
+
+	require.Equal(t, args.Flags[0].String(), stringer.String())
+	require.Equal(t, args.Flags[0].String(), fmt.Sprintf("%v", *args.Flags[0]))
+	require.Contains(t, fmt.Sprintf("%v", *args.Flags[0]), "used=true")
+	require.Equal(t, "--input=in.txt", (*args.Options[0]).Str())
+}
+
+func Test_concurrent_claims_owners_and_unuse(t *testing.T) {
+
+	const N = 64
+
+	args := use_arguments()
+
+	arg := args.Options[0]
+
+	run_concurrently(N, func(i int) {
+
+		switch i % 4 {
+
+		case 0:
+
+			arg.Claim(fmt.Sprintf("component-%d", i))
+		case 1:
+
+			arg.Owner()
+		case 2:
+
+			arg.Unuse()
+		case 3:
+
+			arg.IsUsed()
+			_ = arg.String()
+		}
+	})
+
+	arg.Unuse()
+
+	_, claimed := arg.Owner()
+
+	require.False(t, claimed)
+	require.False(t, arg.IsUsed())
+
+	require.True(t, arg.Claim("final"))
+
+	owner, claimed := arg.Owner()
+
+	require.True(t, claimed)
+	require.Equal(t, "final", owner)
+}
+
+func Test_PeekFlag_and_PeekOption(t *testing.T) {
+
+	args := use_arguments()
+
+	arg, found := args.PeekFlag("--verbose")
+
+	require.True(t, found)
+	require.Equal(t, "-v", arg.GivenName)
+	require.False(t, arg.IsUsed())
+
+	arg, found = args.PeekOption(clasp.Option("--level"))
+
+	require.True(t, found)
+	require.Equal(t, "3", arg.Value)
+	require.False(t, arg.IsUsed())
+
+	_, found = args.PeekFlag("--not-there")
+
+	require.False(t, found)
+
+	require.Panics(t, func() { args.PeekFlag(clasp.Option("--level")) })
+
+	require.Equal(t, 6, len(args.GetUnusedFlagsAndOptions()))
+
+	// a subsequent lookup marks used
+
+	arg, _ = args.LookupOption("--level")
+
+	require.True(t, arg.IsUsed())
+	require.Equal(t, 5, len(args.GetUnusedFlagsAndOptions()))
+}
+
+func Test_Unuse_and_ResetUse(t *testing.T) {
+
+	args := use_arguments()
+
+	require.True(t, args.FlagIsSpecified("--debug"))
+
+	arg, _ := args.PeekFlag("--debug")
+
+	require.True(t, arg.IsUsed())
+
+	arg.Unuse()
+
+	require.False(t, arg.IsUsed())
+	require.Equal(t, 6, len(args.GetUnusedFlagsAndOptions()))
+
+	// unusing a claimed argument releases the claim
+
+	arg, _ = args.ClaimOption("--input", "reader")
+
+	arg.Unuse()
+
+	_, claimed := arg.Owner()
+
+	require.False(t, claimed)
+	require.True(t, arg.Claim("writer"))
+
+	// ResetUse() marks all unused, including values
+
+	args.LookupFlag("--verbose")
+	args.Values[0].Use()
+
+	args.ResetUse()
+
+	require.Equal(t, 6, len(args.GetUnusedFlagsAndOptions()))
+	require.Equal(t, 1, len(args.GetUnusedValues()))
+}
+
+func Test_GetUnusedValues(t *testing.T) {
+
+	args := clasp.Parse([]string{"myprog", "a", "-v", "b", "c"}, clasp.ParseParams{})
+
+	require.Equal(t, 3, len(args.GetUnusedValues()))
+
+	args.Values[1].Use()
+
+	unused := args.GetUnusedValues()
+
+	require.Equal(t, 2, len(unused))
+	require.Equal(t, "a", unused[0].Value)
+	require.Equal(t, "c", unused[1].Value)
+
+	// values are not flags/options
+
+	require.Equal(t, 1, len(args.GetUnusedFlagsAndOptions()))
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
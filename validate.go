@@ -0,0 +1,256 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// Error that reports a problem with a specification, as obtained from
+// [ValidateSpecifications].
+type SpecificationError struct {
+	Index   int    // The index of the specification in the validated specifications.
+	Name    string // The name of the specification.
+	Problem string // The description of the problem.
+}
+
+func (e *SpecificationError) Error() string {
+
+	return fmt.Sprintf("specification[%d] (%q): %s", e.Index, e.Name, e.Problem)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+// Obtains a [SpecificationError] reporting that the specification at the
+// given index is of a type other than flag, option, or section, or `nil`
+// if it is not.
+func specification_type_error(specifications []Specification, i int) error {
+
+	switch specifications[i].Type {
+
+	case FlagType, OptionType, SectionType:
+
+		return nil
+	default:
+
+		return &SpecificationError{
+
+			Index:   i,
+			Name:    specifications[i].Name,
+			Problem: fmt.Sprintf("is of type %v, but must be of type %v, %v, or %v", specifications[i].Type, FlagType, OptionType, SectionType),
+		}
+	}
+}
+
+// Obtains a description of what is wrong with the given flag/option name
+// or alias, or the empty string if it is well-formed.
+func malformed_name_problem(kind string, name string, allow_equals bool) string {
+
+	if "" == name {
+
+		return fmt.Sprintf("%s is empty", kind)
+	}
+
+	if !strings.HasPrefix(name, "-") {
+
+		return fmt.Sprintf("%s %q does not begin with a hyphen", kind, name)
+	}
+
+	if "" == strings.TrimLeft(name, "-") {
+
+		return fmt.Sprintf("%s %q has no characters after its hyphen(s)", kind, name)
+	}
+
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+
+		return fmt.Sprintf("%s %q contains whitespace", kind, name)
+	}
+
+	if !allow_equals && strings.Contains(name, "=") {
+
+		return fmt.Sprintf("%s %q contains '='", kind, name)
+	}
+
+	return ""
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * API
+ */
+
+// Validates the given specifications, obtaining an error that reports all
+// problems found, each as a [SpecificationError], or `nil` if there are
+// none. The problems detected are:
+//
+//   - a specification that is not a flag, option, or section;
+//   - a malformed name or alias, i.e. one that is empty, does not begin
+//     with a hyphen, has nothing after its hyphen(s), contains whitespace,
+//     or, except for the name of an option-value alias, contains '=';
+//   - a name that is the name of another specification;
+//   - an alias that is the name or an alias of another (or the same)
+//     specification;
+//   - an option-value alias - e.g. "--verbosity=chatty" - whose option
+//     does not exist, or whose value is not in the value set of the
+//     option;
+//   - bit flags (see [Specification.SetBitFlags] and
+//     [Specification.SetBitFlags64]) on a specification that is not a
+//     flag;
+//   - a value set (see [Specification.SetValues]) on a specification that
+//     is not an option, or a value set with duplicate values.
+//
+// The error, if not `nil`, may be unwrapped into its individual errors via
+// its `Unwrap() []error` method. See also [Parse_ValidateSpecifications].
+func ValidateSpecifications(specifications []Specification) error {
+
+	var errs []error
+
+	report := func(i int, format string, a ...interface{}) {
+
+		errs = append(errs, &SpecificationError{
+
+			Index:   i,
+			Name:    specifications[i].Name,
+			Problem: fmt.Sprintf(format, a...),
+		})
+	}
+
+	names := make(map[string]int)
+	aliases := make(map[string]int)
+
+	for i, specification := range specifications {
+
+		switch specification.Type {
+
+		case FlagType, OptionType:
+
+			if _, exists := names[specification.Name]; !exists {
+
+				names[specification.Name] = i
+			}
+		}
+	}
+
+	for i, specification := range specifications {
+
+		if err := specification_type_error(specifications, i); err != nil {
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if SectionType == specification.Type && "" == specification.Name {
+
+			report(i, "section name is empty")
+		}
+
+		if SectionType != specification.Type {
+
+			is_option_value_alias := FlagType == specification.Type && strings.Contains(specification.Name, "=")
+
+			if problem := malformed_name_problem("name", specification.Name, is_option_value_alias); "" != problem {
+
+				report(i, "%s", problem)
+			} else if j := names[specification.Name]; j != i {
+
+				report(i, "name %q is also the name of specification[%d]", specification.Name, j)
+			}
+
+			for _, alias := range specification.Aliases {
+
+				if problem := malformed_name_problem("alias", alias, false); "" != problem {
+
+					report(i, "%s", problem)
+				} else if j, exists := names[alias]; exists {
+
+					report(i, "alias %q is the name of specification[%d]", alias, j)
+				} else if j, exists := aliases[alias]; exists {
+
+					report(i, "alias %q is also an alias of specification[%d]", alias, j)
+				} else {
+
+					aliases[alias] = i
+				}
+			}
+
+			if is_option_value_alias {
+
+				ix := strings.Index(specification.Name, "=")
+				option_name := specification.Name[:ix]
+				value := specification.Name[ix+1:]
+
+				if j, exists := names[option_name]; !exists || OptionType != specifications[j].Type {
+
+					report(i, "option-value alias refers to option %q, which is not specified", option_name)
+				} else if 0 != len(specifications[j].ValueSet) {
+
+					found := false
+
+					for _, v := range specifications[j].ValueSet {
+
+						if v == value {
+
+							found = true
+							break
+						}
+					}
+
+					if !found {
+
+						report(i, "option-value alias refers to value %q, which is not in the value set of option %q", value, option_name)
+					}
+				}
+			}
+		}
+
+		if FlagType != specification.Type {
+
+			if 0 != specification.BitFlags || nil != specification.flags_receiver || 0 != specification.BitFlags64 || nil != specification.flags64_receiver {
+
+				report(i, "has bit flags, but is of type %v", specification.Type)
+			}
+		}
+
+		if 0 != len(specification.ValueSet) {
+
+			if OptionType != specification.Type {
+
+				report(i, "has a value set, but is of type %v", specification.Type)
+			} else {
+
+				seen := make(map[string]bool)
+
+				for _, value := range specification.ValueSet {
+
+					if seen[value] {
+
+						report(i, "value %q appears more than once in the value set", value)
+					}
+
+					seen[value] = true
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
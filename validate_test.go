@@ -0,0 +1,104 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+	stegol "github.com/synesissoftware/STEGoL"
+
+	"errors"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ValidateSpecifications_valid(t *testing.T) {
+
+	var flags int
+
+	specifications := []clasp.Specification{
+
+		clasp.Section("behaviour:"),
+		clasp.Option("--verbosity").SetAlias("-V").SetValues("terse", "chatty"),
+		clasp.AliasesFor("--verbosity=chatty", "-c"),
+		clasp.Flag("--debug").SetAlias("-d").SetBitFlags(0x1, &flags),
+
+		clasp.Section("standard:"),
+		clasp.HelpFlag(),
+		clasp.VersionFlag(),
+	}
+
+	require.Nil(t, clasp.ValidateSpecifications(specifications))
+	require.Nil(t, clasp.ValidateSpecifications(nil))
+}
+
+func Test_ValidateSpecifications_reports_all_problems(t *testing.T) {
+
+	var flags int
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--verbose").SetAlias("-v"),
+		clasp.Flag("--verbose"),
+		clasp.Option("--level").SetAliases("-v", "-l", "-l").SetBitFlags(0x2, &flags),
+		clasp.Flag("--quiet").SetValues("yes", "no"),
+		clasp.Option("--colour").SetValues("red", "green", "red"),
+		clasp.AliasesFor("--verbosity=chatty", "-c"),
+		clasp.AliasesFor("--colour=blue", "-b"),
+		clasp.Flag("debug"),
+		clasp.Flag("--dry run"),
+		clasp.Option("--x=y"),
+		clasp.Flag("--"),
+		clasp.Flag("--force").SetAlias("--verbose"),
+		{Type: clasp.ValueType, Name: "value"},
+	}
+
+	err := clasp.ValidateSpecifications(specifications)
+
+	require.NotNil(t, err)
+
+	expected := `specification[1] ("--verbose"): name "--verbose" is also the name of specification[0]
+specification[2] ("--level"): alias "-v" is also an alias of specification[0]
+specification[2] ("--level"): alias "-l" is also an alias of specification[2]
+specification[2] ("--level"): has bit flags, but is of type Option
+specification[3] ("--quiet"): has a value set, but is of type Flag
+specification[4] ("--colour"): value "red" appears more than once in the value set
+specification[5] ("--verbosity=chatty"): option-value alias refers to option "--verbosity", which is not specified
+specification[6] ("--colour=blue"): option-value alias refers to value "blue", which is not in the value set of option "--colour"
+specification[7] ("debug"): name "debug" does not begin with a hyphen
+specification[8] ("--dry run"): name "--dry run" contains whitespace
+specification[9] ("--x=y"): name "--x=y" contains '='
+specification[10] ("--"): name "--" has no characters after its hyphen(s)
+specification[11] ("--force"): alias "--verbose" is the name of specification[0]
+specification[12] ("value"): is of type Value, but must be of type Flag, Option, or Section`
+
+	stegol.CheckStringEqual(t, expected, err.Error())
+
+	var se *clasp.SpecificationError
+
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, 1, se.Index)
+	require.Equal(t, "--verbose", se.Name)
+}
+
+func Test_Parse_ValidateSpecifications(t *testing.T) {
+
+	specifications := []clasp.Specification{
+
+		clasp.Flag("--verbose"),
+		clasp.Flag("--verbose"),
+	}
+
+	require.NotPanics(t, func() {
+
+		clasp.Parse([]string{"myprog"}, clasp.ParseParams{Specifications: specifications})
+	})
+
+	require.PanicsWithValue(t, "invoked Parse() with invalid specifications:\nspecification[1] (\"--verbose\"): name \"--verbose\" is also the name of specification[0]", func() {
+
+		clasp.Parse([]string{"myprog"}, clasp.ParseParams{Specifications: specifications, Flags: clasp.Parse_ValidateSpecifications})
+	})
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
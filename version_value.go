@@ -0,0 +1,283 @@
+// Copyright 2019-2026 Matthew Wilson and Synesis Information Systems.
+// Copyright 2015-2019 Matthew Wilson. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+/*
+ * Created: 19th October 2026
+ * Updated: 19th October 2026
+ */
+
+package clasp
+
+import (
+	"github.com/synesissoftware/ver2go"
+
+	"fmt"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strings"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * types
+ */
+
+// A value of [UsageParams.Version] that causes the version to be derived
+// from the build information of the program (see [debug.ReadBuildInfo]):
+// the version of the main module - without any leading "v" - or, if it is
+// not known, "devel", followed, if not already included, by the semantic
+// version build metadata of the VCS revision (abbreviated to 12
+// characters) and ".dirty" if the working tree had been modified, e.g.
+// "devel+0123456789ab.dirty".
+//
+// Typically specified as [VersionFromBuildInfo].
+type BuildInfoVersion struct {
+	// Function used to read the build information. If `nil`,
+	// [debug.ReadBuildInfo] is used.
+	ReadBuildInfo func() (*debug.BuildInfo, bool)
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * constants
+ */
+
+// A value of [UsageParams.Version] that causes the version to be derived
+// from the build information of the program (see [BuildInfoVersion]).
+var VersionFromBuildInfo = BuildInfoVersion{}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helpers
+ */
+
+func build_info_version_string(biv BuildInfoVersion) (string, error) {
+
+	read_build_info := biv.ReadBuildInfo
+	if nil == read_build_info {
+
+		read_build_info = debug.ReadBuildInfo
+	}
+
+	info, ok := read_build_info()
+	if !ok || nil == info {
+
+		return "", fmt.Errorf("build information is not available")
+	}
+
+	version := strings.TrimPrefix(info.Main.Version, "v")
+	if "" == version || "(devel)" == version {
+
+		version = "devel"
+	}
+
+	if strings.Contains(version, "+") {
+
+		return version, nil
+	}
+
+	var revision string
+	var modified bool
+
+	for _, setting := range info.Settings {
+
+		switch setting.Key {
+
+		case "vcs.revision":
+
+			revision = setting.Value
+		case "vcs.modified":
+
+			modified = "true" == setting.Value
+		}
+	}
+
+	var metadata []string
+
+	if "" != revision {
+
+		metadata = append(metadata, revision[:min(12, len(revision))])
+	}
+	if modified {
+
+		metadata = append(metadata, "dirty")
+	}
+
+	if 0 != len(metadata) {
+
+		version += "+" + strings.Join(metadata, ".")
+	}
+
+	return version, nil
+}
+
+func struct_field_uint(v reflect.Value, name string) (uint64, bool, bool) {
+
+	f := v.FieldByName(name)
+
+	if !f.IsValid() {
+
+		return 0, false, true
+	}
+
+	switch f.Kind() {
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		if f.Int() < 0 {
+
+			return 0, true, false
+		}
+
+		return uint64(f.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		return f.Uint(), true, true
+	default:
+
+		return 0, true, false
+	}
+}
+
+func struct_field_string(v reflect.Value, names ...string) string {
+
+	for _, name := range names {
+
+		if f := v.FieldByName(name); f.IsValid() && reflect.String == f.Kind() {
+
+			return f.String()
+		}
+	}
+
+	return ""
+}
+
+// Obtains the version string of a structure having (integral) fields
+// `Major`, `Minor`, and `Patch`, and either an `AB` field, interpreted as
+// by ver2go, or optional (string) fields `PreRelease` (or `Prerelease`)
+// and `Build` (or `Metadata`), interpreted as by semantic versioning, and
+// whether the value is such a structure. An error is returned if it has an
+// `AB` field and any of these fields exceeds the range of `uint16`.
+func struct_version_string(v reflect.Value) (version string, is_version bool, err error) {
+
+	for reflect.Pointer == v.Kind() && !v.IsNil() {
+
+		v = v.Elem()
+	}
+
+	if reflect.Struct != v.Kind() {
+
+		return "", false, nil
+	}
+
+	var parts [3]uint64
+
+	for i, name := range []string{"Major", "Minor", "Patch"} {
+
+		n, present, valid := struct_field_uint(v, name)
+		if !present || !valid {
+
+			return "", false, nil
+		}
+
+		parts[i] = n
+	}
+
+	if ab, present, valid := struct_field_uint(v, "AB"); present {
+
+		if !valid {
+
+			return "", false, nil
+		}
+
+		names := []string{"Major", "Minor", "Patch", "AB"}
+
+		for i, n := range append(parts[:], ab) {
+
+			if n > math.MaxUint16 {
+
+				return "", true, fmt.Errorf("field %s, %d, exceeds the maximum, %d, of a version having an AB field", names[i], n, math.MaxUint16)
+			}
+		}
+
+		return ver2go.CalcVersionString(uint16(parts[0]), uint16(parts[1]), uint16(parts[2]), uint16(ab)), true, nil
+	}
+
+	version = fmt.Sprintf("%d.%d.%d", parts[0], parts[1], parts[2])
+
+	if pre_release := struct_field_string(v, "PreRelease", "Prerelease"); "" != pre_release {
+
+		version += "-" + pre_release
+	}
+
+	if build := struct_field_string(v, "Build", "Metadata"); "" != build {
+
+		version += "+" + build
+	}
+
+	return version, true, nil
+}
+
+func join_version_parts[T any](parts []T) string {
+
+	as := make([]string, len(parts))
+
+	for i, part := range parts {
+
+		as[i] = fmt.Sprintf("%v", part)
+	}
+
+	return strings.Join(as, ".")
+}
+
+// Obtains the version string of the given value of [UsageParams.Version].
+func version_value_string(version interface{}, apiFunctionName string) (string, error) {
+
+	switch v := version.(type) {
+
+	case string:
+
+		return v, nil
+	case []string:
+
+		return strings.Join(v, "."), nil
+	case []int:
+
+		return join_version_parts(v), nil
+	case []uint16:
+
+		return join_version_parts(v), nil
+	case uint64:
+
+		return ver2go.CalcVersionString(uint16(v>>48), uint16(v>>32), uint16(v>>16), uint16(v)), nil
+	case BuildInfoVersion:
+
+		s, err := build_info_version_string(v)
+		if err != nil {
+
+			return "", fmt.Errorf("%s() could not derive version from build information: %w", apiFunctionName, err)
+		}
+
+		return s, nil
+	case fmt.Stringer:
+
+		return v.String(), nil
+	}
+
+	if nil != version {
+
+		if s, ok, err := struct_version_string(reflect.ValueOf(version)); ok {
+
+			if err != nil {
+
+				return "", fmt.Errorf("%s() called with UsageParams.Version of type %T whose %w", apiFunctionName, version, err)
+			}
+
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s() called with UsageParams.Version of an invalid type %T, but must be instance of string, []string, []int, []uint16, uint64, fmt.Stringer, BuildInfoVersion, or a structure with Major, Minor, and Patch fields", apiFunctionName, version)
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */
@@ -0,0 +1,201 @@
+package clasp_test
+
+import (
+	"github.com/stretchr/testify/require"
+	clasp "github.com/synesissoftware/CLASP.Go"
+
+	"bytes"
+	"runtime/debug"
+	"testing"
+)
+
+/* /////////////////////////////////////////////////////////////////////////
+ * helper functions
+ */
+
+type stringer_version struct{}
+
+func (sv stringer_version) String() string {
+
+	return "3.2.1-stringer"
+}
+
+type ab_version struct {
+	Major, Minor, Patch, AB uint16
+}
+
+type semver_version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string
+	Build      string
+}
+
+func show_version_(t *testing.T, version interface{}) (string, error) {
+
+	t.Helper()
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowVersion(nil, clasp.UsageParams{
+
+		Stream:      stream,
+		ProgramName: "myprog",
+		UsageFlags:  clasp.DontCallExit,
+		Version:     version,
+	})
+
+	return stream.String(), err
+}
+
+func fake_build_info(version string, settings ...debug.BuildSetting) clasp.BuildInfoVersion {
+
+	return clasp.BuildInfoVersion{
+
+		ReadBuildInfo: func() (*debug.BuildInfo, bool) {
+
+			info := &debug.BuildInfo{Settings: settings}
+
+			info.Main.Version = version
+
+			return info, true
+		},
+	}
+}
+
+/* /////////////////////////////////////////////////////////////////////////
+ * test functions
+ */
+
+func Test_ShowVersion_with_packed_uint64_version(t *testing.T) {
+
+	for _, tc := range []struct {
+		version  uint64
+		expected string
+	}{
+		{0x0001000200030000 | 0xFFFF, "myprog 1.2.3\n"},
+		{0x0001000200034002, "myprog 1.2.3-alpha2\n"},
+		{0x000100020003C001, "myprog 1.2.3-rc1\n"},
+		{clasp.Version, "myprog " + clasp.VersionString() + "\n"},
+	} {
+
+		actual, err := show_version_(t, tc.version)
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, actual)
+	}
+}
+
+func Test_ShowVersion_with_struct_version(t *testing.T) {
+
+	actual, err := show_version_(t, ab_version{Major: 1, Minor: 2, Patch: 3, AB: 0x8004})
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 1.2.3-beta4\n", actual)
+
+	actual, err = show_version_(t, &semver_version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", Build: "20261019"})
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 1.2.3-rc.1+20261019\n", actual)
+
+	actual, err = show_version_(t, struct{ Major, Minor, Patch int }{0, 9, 12})
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 0.9.12\n", actual)
+}
+
+func Test_ShowVersion_with_struct_version_out_of_range(t *testing.T) {
+
+	type ab_version_32 struct {
+		Major, Minor, Patch, AB uint32
+	}
+
+	actual, err := show_version_(t, ab_version_32{Major: 70000, Minor: 2, Patch: 3, AB: 0x8004})
+
+	require.NotNil(t, err)
+	require.Equal(t, "", actual)
+	require.Equal(t, "ShowVersion() called with UsageParams.Version of type clasp_test.ab_version_32 whose field Major, 70000, exceeds the maximum, 65535, of a version having an AB field", err.Error())
+
+	_, err = show_version_(t, ab_version_32{Major: 1, Minor: 2, Patch: 3, AB: 0x10000})
+
+	require.NotNil(t, err)
+
+	// without an AB field, there is no such limit
+
+	actual, err = show_version_(t, struct{ Major, Minor, Patch int }{70000, 0, 1})
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 70000.0.1\n", actual)
+}
+
+func Test_ShowVersion_with_Stringer_version(t *testing.T) {
+
+	actual, err := show_version_(t, stringer_version{})
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 3.2.1-stringer\n", actual)
+}
+
+func Test_ShowVersion_with_build_info_version(t *testing.T) {
+
+	actual, err := show_version_(t, fake_build_info("v1.4.0"))
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 1.4.0\n", actual)
+
+	actual, err = show_version_(t, fake_build_info("(devel)", debug.BuildSetting{Key: "vcs.revision", Value: "0123456789abcdef0123"}, debug.BuildSetting{Key: "vcs.modified", Value: "true"}))
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog devel+0123456789ab.dirty\n", actual)
+
+	actual, err = show_version_(t, fake_build_info("v1.4.1-0.20261019000000-0123456789ab+dirty", debug.BuildSetting{Key: "vcs.revision", Value: "0123456789abcdef0123"}))
+
+	require.Nil(t, err)
+	require.Equal(t, "myprog 1.4.1-0.20261019000000-0123456789ab+dirty\n", actual)
+}
+
+func Test_ShowVersion_with_unavailable_build_info(t *testing.T) {
+
+	_, err := show_version_(t, clasp.BuildInfoVersion{
+
+		ReadBuildInfo: func() (*debug.BuildInfo, bool) {
+
+			return nil, false
+		},
+	})
+
+	require.NotNil(t, err)
+	require.Equal(t, "ShowVersion() could not derive version from build information: build information is not available", err.Error())
+}
+
+func Test_ShowVersion_with_invalid_version_type(t *testing.T) {
+
+	for _, version := range []interface{}{nil, 1.2, []float64{1, 2}, struct{ Major, Minor int }{1, 2}} {
+
+		actual, err := show_version_(t, version)
+
+		require.NotNil(t, err, "for %v", version)
+		require.Contains(t, err.Error(), "ShowVersion() called with UsageParams.Version of an invalid type")
+		require.Equal(t, "", actual)
+	}
+}
+
+func Test_ShowUsage_with_invalid_version_type(t *testing.T) {
+
+	stream := new(bytes.Buffer)
+
+	_, err := clasp.ShowUsage(nil, clasp.UsageParams{
+
+		Stream:     stream,
+		UsageFlags: clasp.DontCallExit,
+		Version:    3.14,
+		InfoLines:  []string{"myprog", ":version:"},
+	})
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "ShowUsage() called with UsageParams.Version of an invalid type float64")
+	require.Equal(t, "", stream.String())
+}
+
+/* ///////////////////////////// end of file //////////////////////////// */